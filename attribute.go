@@ -0,0 +1,53 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultJSONAttributeLength bounds JSON attribute values when no attribute value length limit is configured
+const defaultJSONAttributeLength = 4096
+
+// jsonAttributeLength reads the span attribute value length limit from the standard environment variables
+var jsonAttributeLength = sync.OnceValue(func() int {
+	for _, key := range []string{"OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT", "OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT"} {
+		if limit, err := strconv.Atoi(os.Getenv(key)); err == nil && limit > 0 {
+			return limit
+		}
+	}
+
+	return defaultJSONAttributeLength
+})
+
+// JSONAttribute marshals the value to a JSON string attribute, for structs and maps that have no attribute type.
+// Values are truncated to the attribute value length limit of OTEL_SPAN_ATTRIBUTE_VALUE_LENGTH_LIMIT or
+// OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT, 4096 bytes by default. Values that fail to marshal are formatted with %+v
+func JSONAttribute(key string, v any) attribute.KeyValue {
+	var value string
+	if b, err := json.Marshal(v); err == nil {
+		value = string(b)
+	} else {
+		value = fmt.Sprintf("%+v", v)
+	}
+
+	return attribute.String(key, truncateUTF8(value, jsonAttributeLength()))
+}
+
+// truncateUTF8 cuts the string to at most limit bytes without splitting a multi byte character
+func truncateUTF8(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+
+	return s[:limit]
+}
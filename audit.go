@@ -0,0 +1,141 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// AuditKey marks spans and log records as audit events. Audit events are always sampled and exported
+	// synchronously with retries when Config.EnableAudit is set
+	AuditKey = attribute.Key("telemetry.audit")
+
+	defaultAuditTimeout = 30 * time.Second
+	auditRetryDelay     = 100 * time.Millisecond
+	auditMaxRetryDelay  = 5 * time.Second
+)
+
+// AuditAttribute marks a span as an audit event, e.g. tracer.Start(ctx, name, trace.WithAttributes(AuditAttribute))
+var AuditAttribute = AuditKey.Bool(true)
+
+// AuditLogAttribute marks a log record as an audit event
+var AuditLogAttribute = log.Bool(string(AuditKey), true)
+
+// auditSpanProcessor exports audit spans synchronously with retries until the timeout, and passes every other span
+// to the wrapped processor
+type auditSpanProcessor struct {
+	sdktrace.SpanProcessor
+
+	exporter sdktrace.SpanExporter
+	timeout  time.Duration
+}
+
+func (p auditSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !isAuditSpan(s.Attributes()) {
+		p.SpanProcessor.OnEnd(s)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	err := retryUntilDeadline(ctx, func(ctx context.Context) error {
+		return p.exporter.ExportSpans(ctx, []sdktrace.ReadOnlySpan{s})
+	})
+	if err != nil {
+		otel.Handle(AuditExportError{err})
+	}
+}
+
+// auditLogProcessor exports audit log records synchronously with retries until the timeout, and passes every other
+// record to the wrapped processor
+type auditLogProcessor struct {
+	sdklog.Processor
+
+	exporter sdklog.Exporter
+	timeout  time.Duration
+}
+
+func (p auditLogProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if !isAuditRecord(record) {
+		return p.Processor.OnEmit(ctx, record)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.timeout)
+	defer cancel()
+
+	records := []sdklog.Record{record.Clone()}
+	err := retryUntilDeadline(ctx, func(ctx context.Context) error {
+		return p.exporter.Export(ctx, records)
+	})
+	if err != nil {
+		return AuditExportError{err}
+	}
+
+	return nil
+}
+
+// auditSampler always samples spans started with the audit attribute
+type auditSampler struct {
+	sdktrace.Sampler
+}
+
+func (s auditSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if !isAuditSpan(p.Attributes) {
+		return s.Sampler.ShouldSample(p)
+	}
+
+	return sdktrace.AlwaysSample().ShouldSample(p)
+}
+
+func (s auditSampler) Description() string {
+	return "AuditSampler{" + s.Sampler.Description() + "}"
+}
+
+func isAuditSpan(attrs []attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == AuditAttribute {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAuditRecord(record *sdklog.Record) bool {
+	audit := false
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		audit = kv.Equal(AuditLogAttribute)
+		return !audit
+	})
+
+	return audit
+}
+
+// retryUntilDeadline calls fn with exponential backoff until it succeeds or the context is done
+func retryUntilDeadline(ctx context.Context, fn func(context.Context) error) error {
+	delay := auditRetryDelay
+
+	for {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, auditMaxRetryDelay)
+	}
+}
@@ -0,0 +1,146 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// CircuitBreakerConfig configures the circuit breaker that protects the application from a degraded collector
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed exports that opens the circuit. Defaults to 5
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before a single probe export is attempted. Defaults to 30 seconds
+	Cooldown time.Duration
+}
+
+// circuitBreaker tracks consecutive export failures. While open, exports are dropped locally until the cooldown
+// passes and a probe export succeeds
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(cfg *CircuitBreakerConfig) *circuitBreaker {
+	breaker := &circuitBreaker{
+		threshold: cfg.FailureThreshold,
+		cooldown:  cfg.Cooldown,
+	}
+
+	if breaker.threshold <= 0 {
+		breaker.threshold = defaultBreakerThreshold
+	}
+
+	if breaker.cooldown <= 0 {
+		breaker.cooldown = defaultBreakerCooldown
+	}
+
+	return breaker
+}
+
+// allow reports whether an export should be attempted and whether it is the probe. Once the cooldown passes only one
+// probe runs at a time
+func (b *circuitBreaker) allow() (ok bool, probe bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true, false
+	}
+
+	if b.probing || time.Now().Before(b.openUntil) {
+		return false, false
+	}
+
+	b.probing = true
+
+	return true, true
+}
+
+// record updates the breaker with the result of an attempted export. Only the result of the probe ends the probe, so
+// exports started before the circuit opened can't let a second probe through
+func (b *circuitBreaker) record(probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	}
+
+	if err == nil {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+type breakerSpanExporter struct {
+	sdktrace.SpanExporter
+	breaker *circuitBreaker
+}
+
+func (e *breakerSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	ok, probe := e.breaker.allow()
+	if !ok {
+		return nil
+	}
+
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.breaker.record(probe, err)
+
+	return err
+}
+
+type breakerMetricExporter struct {
+	sdkmetric.Exporter
+	breaker *circuitBreaker
+}
+
+func (e *breakerMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	ok, probe := e.breaker.allow()
+	if !ok {
+		return nil
+	}
+
+	err := e.Exporter.Export(ctx, rm)
+	e.breaker.record(probe, err)
+
+	return err
+}
+
+type breakerLogExporter struct {
+	sdklog.Exporter
+	breaker *circuitBreaker
+}
+
+func (e *breakerLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	ok, probe := e.breaker.allow()
+	if !ok {
+		return nil
+	}
+
+	err := e.Exporter.Export(ctx, records)
+	e.breaker.record(probe, err)
+
+	return err
+}
@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errExport = errors.New("export failed")
+
+// openBreaker returns a breaker whose circuit is open and whose cooldown has passed
+func openBreaker(t *testing.T) *circuitBreaker {
+	t.Helper()
+
+	breaker := newCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Hour})
+	for range 2 {
+		if ok, _ := breaker.allow(); !ok {
+			t.Fatal("allow() = false before the threshold")
+		}
+		breaker.record(false, errExport)
+	}
+
+	if ok, _ := breaker.allow(); ok {
+		t.Fatal("allow() = true while the circuit is open")
+	}
+
+	breaker.openUntil = time.Now().Add(-time.Second)
+
+	return breaker
+}
+
+func TestNewCircuitBreakerDefaults(t *testing.T) {
+	breaker := newCircuitBreaker(&CircuitBreakerConfig{})
+
+	if breaker.threshold != defaultBreakerThreshold || breaker.cooldown != defaultBreakerCooldown {
+		t.Errorf("threshold, cooldown = %d, %v, want %d, %v", breaker.threshold, breaker.cooldown,
+			defaultBreakerThreshold, defaultBreakerCooldown)
+	}
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	tests := []struct {
+		name string
+
+		// inFlight is the result of an export started before the circuit opened, recorded during the probe
+		inFlight *error
+		probeErr error
+		wantOk   bool
+	}{
+		{
+			name:   "successful probe closes the circuit",
+			wantOk: true,
+		},
+		{
+			name:     "failed probe reopens the circuit",
+			probeErr: errExport,
+		},
+		{
+			name:     "failed in-flight export keeps the probe running",
+			inFlight: &errExport,
+			probeErr: errExport,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := openBreaker(t)
+
+			ok, probe := breaker.allow()
+			if !ok || !probe {
+				t.Fatalf("allow() = %v, %v, want the probe", ok, probe)
+			}
+
+			if ok, _ := breaker.allow(); ok {
+				t.Fatal("allow() = true during the probe")
+			}
+
+			if tt.inFlight != nil {
+				breaker.record(false, *tt.inFlight)
+
+				// the failure restarts the cooldown, skip it so only the running probe holds other exports back
+				breaker.openUntil = time.Now().Add(-time.Second)
+
+				if ok, _ := breaker.allow(); ok {
+					t.Fatal("allow() = true after an in-flight result during the probe")
+				}
+			}
+
+			breaker.record(probe, tt.probeErr)
+
+			if ok, probe := breaker.allow(); ok != tt.wantOk || probe {
+				t.Errorf("allow() after the probe = %v, %v, want %v, false", ok, probe, tt.wantOk)
+			}
+		})
+	}
+}
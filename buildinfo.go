@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	vcsRevisionKey = attribute.Key("vcs.repository.ref.revision")
+	vcsTimeKey     = attribute.Key("vcs.time")
+	buildTimeKey   = attribute.Key("build.time")
+)
+
+// BuildInfo identifies the build that produced the telemetry, typically set with -ldflags. Empty fields fall back to
+// the version control information embedded by the Go toolchain when available
+type BuildInfo struct {
+	// Version is recorded as service.version
+	Version string
+
+	// Commit is recorded as vcs.repository.ref.revision
+	Commit string
+
+	// CommitTime is the time of the commit, recorded as vcs.time
+	CommitTime string
+
+	// BuildTime is recorded as build.time. It has no fallback, the toolchain does not embed the build time
+	BuildTime string
+}
+
+// attributes returns the resource attributes of the build
+func (b BuildInfo) attributes() []attribute.KeyValue {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		b = b.withToolchainInfo(info)
+	}
+
+	attrs := make([]attribute.KeyValue, 0, 4)
+	if b.Version != "" {
+		attrs = append(attrs, semconv.ServiceVersion(b.Version))
+	}
+	if b.Commit != "" {
+		attrs = append(attrs, vcsRevisionKey.String(b.Commit))
+	}
+	if b.CommitTime != "" {
+		attrs = append(attrs, vcsTimeKey.String(b.CommitTime))
+	}
+	if b.BuildTime != "" {
+		attrs = append(attrs, buildTimeKey.String(b.BuildTime))
+	}
+
+	return attrs
+}
+
+// withToolchainInfo fills the empty version, commit and commit time from the build information of the Go toolchain
+func (b BuildInfo) withToolchainInfo(info *debug.BuildInfo) BuildInfo {
+	if b.Version == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		b.Version = info.Main.Version
+	}
+
+	for _, setting := range info.Settings {
+		switch {
+		case setting.Key == "vcs.revision" && b.Commit == "":
+			b.Commit = setting.Value
+		case setting.Key == "vcs.time" && b.CommitTime == "":
+			b.CommitTime = setting.Value
+		}
+	}
+
+	return b
+}
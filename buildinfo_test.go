@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+func TestBuildInfoWithToolchainInfo(t *testing.T) {
+	info := &debug.BuildInfo{
+		Main: debug.Module{Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2024-01-02T03:04:05Z"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		build BuildInfo
+		info  *debug.BuildInfo
+		want  BuildInfo
+	}{
+		{
+			name: "falls back to the toolchain",
+			info: info,
+			want: BuildInfo{Version: "v1.2.3", Commit: "abc123", CommitTime: "2024-01-02T03:04:05Z"},
+		},
+		{
+			name:  "keeps the given fields",
+			build: BuildInfo{Version: "v2.0.0", Commit: "def456", CommitTime: "2025-01-01T00:00:00Z", BuildTime: "now"},
+			info:  info,
+			want:  BuildInfo{Version: "v2.0.0", Commit: "def456", CommitTime: "2025-01-01T00:00:00Z", BuildTime: "now"},
+		},
+		{
+			name: "ignores the devel version",
+			info: &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}},
+			want: BuildInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.build.withToolchainInfo(tt.info); got != tt.want {
+				t.Errorf("withToolchainInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildInfoAttributes(t *testing.T) {
+	build := BuildInfo{Version: "v1.2.3", Commit: "abc123", CommitTime: "2024-01-02T03:04:05Z", BuildTime: "2024-01-03T00:00:00Z"}
+
+	got := make(map[string]string)
+	for _, attr := range build.attributes() {
+		got[string(attr.Key)] = attr.Value.AsString()
+	}
+
+	want := map[string]string{
+		"service.version":             "v1.2.3",
+		"vcs.repository.ref.revision": "abc123",
+		"vcs.time":                    "2024-01-02T03:04:05Z",
+		"build.time":                  "2024-01-03T00:00:00Z",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("attributes() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Carried bundles a value with the span context and baggage it was produced in, so trace context survives channel
+// based pipelines. Create it with Wrap and restore the context on the receiving side with Unwrap
+type Carried[T any] struct {
+	Value T
+
+	spanContext trace.SpanContext
+	baggage     baggage.Baggage
+}
+
+// Wrap bundles the value with the span context and baggage of ctx
+func Wrap[T any](ctx context.Context, v T) Carried[T] {
+	return Carried[T]{
+		Value:       v,
+		spanContext: trace.SpanContextFromContext(ctx),
+		baggage:     baggage.FromContext(ctx),
+	}
+}
+
+// Unwrap returns ctx carrying the span context and baggage of c along with its value. Spans started from the returned
+// context are children of the span that was active when the value was wrapped
+func Unwrap[T any](ctx context.Context, c Carried[T]) (context.Context, T) {
+	if c.spanContext.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, c.spanContext)
+	}
+
+	if c.baggage.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, c.baggage)
+	}
+
+	return ctx, c.Value
+}
@@ -0,0 +1,102 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type debugTrace struct {
+	TraceID string      `json:"trace_id"`
+	Spans   []debugSpan `json:"spans"`
+}
+
+type debugSpan struct {
+	Name         string            `json:"name"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	Kind         string            `json:"kind"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	DurationMs   float64           `json:"duration_ms"`
+	Status       string            `json:"status"`
+	Description  string            `json:"status_description,omitempty"`
+	Sampled      bool              `json:"sampled"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// DebugHandler renders the traces in the debug span buffer as JSON, most recent trace first. It is meant to be
+// mounted on an internal route such as /debug/traces and responds with 404 when Config.DebugSpanBufferSize is unset
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buffer := recentSpans.Load()
+		if buffer == nil {
+			http.Error(w, "debug span buffer is not enabled", http.StatusNotFound)
+			return
+		}
+
+		// encoded before writing so a failure can still be reported with a 500
+		body, err := json.MarshalIndent(groupTraces(buffer.spans()), "", "  ")
+		if err != nil {
+			otel.Handle(err)
+			http.Error(w, "failed to encode debug traces", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(append(body, '\n'))
+	})
+}
+
+// groupTraces groups spans by trace, ordering traces by their most recently ended span
+func groupTraces(spans []sdktrace.ReadOnlySpan) []debugTrace {
+	index := make(map[string]int)
+	traces := make([]debugTrace, 0)
+
+	for i := len(spans) - 1; i >= 0; i-- {
+		span := spans[i]
+		traceID := span.SpanContext().TraceID().String()
+
+		pos, ok := index[traceID]
+		if !ok {
+			pos = len(traces)
+			index[traceID] = pos
+			traces = append(traces, debugTrace{TraceID: traceID})
+		}
+
+		traces[pos].Spans = append(traces[pos].Spans, newDebugSpan(span))
+	}
+
+	return traces
+}
+
+func newDebugSpan(span sdktrace.ReadOnlySpan) debugSpan {
+	debug := debugSpan{
+		Name:        span.Name(),
+		SpanID:      span.SpanContext().SpanID().String(),
+		Kind:        span.SpanKind().String(),
+		Start:       span.StartTime(),
+		End:         span.EndTime(),
+		DurationMs:  float64(span.EndTime().Sub(span.StartTime())) / float64(time.Millisecond),
+		Status:      span.Status().Code.String(),
+		Description: span.Status().Description,
+		Sampled:     span.SpanContext().IsSampled(),
+	}
+
+	if span.Parent().IsValid() {
+		debug.ParentSpanID = span.Parent().SpanID().String()
+	}
+
+	if attrs := span.Attributes(); len(attrs) > 0 {
+		debug.Attributes = make(map[string]string, len(attrs))
+		for _, attr := range attrs {
+			debug.Attributes[string(attr.Key)] = attr.Value.Emit()
+		}
+	}
+
+	return debug
+}
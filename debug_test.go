@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestDebugHandler(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	})
+
+	tests := []struct {
+		name       string
+		spans      []tracetest.SpanStub
+		noBuffer   bool
+		wantStatus int
+	}{
+		{
+			name:       "renders the buffered traces",
+			spans:      []tracetest.SpanStub{{Name: "request", SpanContext: spanContext, StartTime: start, EndTime: start.Add(time.Second)}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "buffer disabled",
+			noBuffer:   true,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "span that can't be encoded",
+			spans: []tracetest.SpanStub{{
+				Name:        "request",
+				SpanContext: spanContext,
+				StartTime:   start,
+				EndTime:     time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+			}},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	t.Cleanup(func() { recentSpans.Store(nil) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recentSpans.Store(nil)
+			if !tt.noBuffer {
+				buffer, err := newSpanBuffer(8, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, span := range tracetest.SpanStubs(tt.spans).Snapshots() {
+					buffer.OnEnd(span)
+				}
+				recentSpans.Store(buffer)
+			}
+
+			recorder := httptest.NewRecorder()
+			DebugHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/traces", nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var traces []debugTrace
+			if err := json.Unmarshal(recorder.Body.Bytes(), &traces); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+
+			if len(traces) != 1 || len(traces[0].Spans) != 1 || traces[0].Spans[0].Name != "request" {
+				t.Errorf("traces = %+v, want the request span", traces)
+			}
+		})
+	}
+}
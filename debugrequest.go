@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDKey is the attribute carrying the ID of a request marked with WithDebugRequest
+const requestIDKey = attribute.Key("request.id")
+
+// debugRequests holds the number of requests that may still be marked for debugging, set by InitProviders
+var debugRequests atomic.Int64
+
+// WithDebugRequest marks the request for a targeted investigation. The request ID is set as the request.id attribute
+// on the active span and added to the measurements of Incr and Record made with the returned context. At most
+// Config.MaxDebugRequests requests are marked over the lifetime of the providers to bound metric cardinality, after
+// which the context is returned unchanged
+func WithDebugRequest(ctx context.Context, requestID string) context.Context {
+	if _, ok := ctx.Value(DebugRequestCtxKey{}).(attribute.KeyValue); ok {
+		return ctx
+	}
+
+	for {
+		remaining := debugRequests.Load()
+		if remaining <= 0 {
+			return ctx
+		}
+
+		if debugRequests.CompareAndSwap(remaining, remaining-1) {
+			break
+		}
+	}
+
+	attr := requestIDKey.String(requestID)
+	trace.SpanFromContext(ctx).SetAttributes(attr)
+
+	return context.WithValue(ctx, DebugRequestCtxKey{}, attr)
+}
+
+// withDebugRequestAttribute appends the request ID of a request marked with WithDebugRequest to the attributes
+func withDebugRequestAttribute(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	attr, ok := ctx.Value(DebugRequestCtxKey{}).(attribute.KeyValue)
+	if !ok {
+		return attrs
+	}
+
+	return slices.Concat(attrs, []attribute.KeyValue{attr})
+}
@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/contrib/detectors/aws/ec2"
+	"go.opentelemetry.io/contrib/detectors/aws/ecs"
+	"go.opentelemetry.io/contrib/detectors/aws/eks"
+	lambdadetector "go.opentelemetry.io/contrib/detectors/aws/lambda"
+	"go.opentelemetry.io/contrib/detectors/gcp"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// platformDetector is a resource detector enabled by a Config flag or Config.AutoDetect
+type platformDetector struct {
+	name    string
+	enabled bool
+
+	// detector creates the detector lazily, some detectors set up clients when created
+	detector func() resource.Detector
+}
+
+// detectResource runs the enabled resource detectors and merges their resources in order, platform detectors taking
+// precedence over host detection, or nil when none is enabled. With Config.AutoDetect every platform detector runs
+// and the ones that fail, e.g. because the process does not run on their platform, are skipped. Detectors enabled by
+// their own flag fail initialization instead
+func detectResource(ctx context.Context, cfg *Config) (*resource.Resource, error) {
+	detectors := []platformDetector{
+		{"lambda", cfg.Lambda, func() resource.Detector { return lambdadetector.NewResourceDetector() }},
+		{"ecs", cfg.ECS, ecs.NewResourceDetector},
+		{"ec2", cfg.EC2, func() resource.Detector { return ec2.NewResourceDetector() }},
+		{"eks", cfg.EKS, eks.NewResourceDetector},
+		{"gcp", cfg.GCP, gcp.NewDetector},
+	}
+
+	var detected *resource.Resource
+	if cfg.HostDetection {
+		// process command args are left out since they may contain secrets
+		hostResource, err := resource.New(ctx,
+			resource.WithHost(),
+			resource.WithOS(),
+			resource.WithProcessPID(),
+			resource.WithProcessExecutableName(),
+			resource.WithProcessExecutablePath(),
+			resource.WithProcessRuntimeName(),
+			resource.WithProcessRuntimeVersion(),
+			resource.WithProcessRuntimeDescription(),
+		)
+		if err != nil {
+			return nil, ResourceDetectorError{"host", err}
+		}
+
+		detected = hostResource
+	}
+
+	for _, d := range detectors {
+		if !d.enabled && !cfg.AutoDetect {
+			continue
+		}
+
+		res, err := d.detector().Detect(ctx)
+		switch {
+		case err != nil && !d.enabled:
+			continue
+		case err != nil && d.name == "lambda":
+			return nil, LambdaResourceError{err}
+		case err != nil:
+			return nil, ResourceDetectorError{d.name, err}
+		}
+
+		detected, err = mergeResources(cfg, detected, res)
+		if err != nil {
+			return nil, ResourceMergeError{err}
+		}
+	}
+
+	return detected, nil
+}
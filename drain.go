@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// draining is set by BeginDrain to stop sampling new root spans
+var draining atomic.Bool
+
+// BeginDrain prepares for a rolling restart. New local root spans are no longer recorded while in-flight spans and
+// their children still end and export normally, and the global trace and meter providers are flushed in the
+// background within the shutdown timeout. Run the regular cleanup function afterwards to shut the providers down
+func BeginDrain() {
+	draining.Store(true)
+
+	timeout := defaultShutdownTimeout
+	if providers := activeProviders.Load(); providers != nil && providers.timeout > 0 {
+		timeout = providers.timeout
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		if traceProvider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+			if err := traceProvider.ForceFlush(ctx); err != nil {
+				otel.Handle(err)
+			}
+		}
+
+		if meterProvider, ok := otel.GetMeterProvider().(*sdkmetric.MeterProvider); ok {
+			if err := meterProvider.ForceFlush(ctx); err != nil {
+				otel.Handle(err)
+			}
+		}
+	}()
+}
+
+// drainSampler drops new local root spans except audit spans once draining has begun. Children of in-flight local
+// spans are still delegated so their traces stay complete
+type drainSampler struct {
+	sdktrace.Sampler
+}
+
+func (s drainSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanContextFromContext(p.ParentContext)
+	localChild := parent.IsValid() && !parent.IsRemote()
+
+	if draining.Load() && !localChild && !isAuditSpan(p.Attributes) {
+		return sdktrace.NeverSample().ShouldSample(p)
+	}
+
+	return s.Sampler.ShouldSample(p)
+}
+
+func (s drainSampler) Description() string {
+	return "DrainSampler{" + s.Sampler.Description() + "}"
+}
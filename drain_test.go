@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestDrainSampler(t *testing.T) {
+	draining.Store(true)
+	t.Cleanup(func() { draining.Store(false) })
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	tests := []struct {
+		name   string
+		parent context.Context
+		want   sdktrace.SamplingDecision
+	}{
+		{name: "new root", parent: context.Background(), want: sdktrace.Drop},
+		{name: "remote parent", parent: trace.ContextWithRemoteSpanContext(context.Background(), parent), want: sdktrace.Drop},
+		{name: "local parent", parent: trace.ContextWithSpanContext(context.Background(), parent), want: sdktrace.RecordAndSample},
+	}
+
+	sampler := drainSampler{sdktrace.AlwaysSample()}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: tt.parent,
+				TraceID:       parent.TraceID(),
+				Name:          "span",
+			})
+
+			if result.Decision != tt.want {
+				t.Errorf("decision = %v, want %v", result.Decision, tt.want)
+			}
+		})
+	}
+}
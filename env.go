@@ -0,0 +1,229 @@
+package telemetry
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// default OTLP endpoints of ConfigFromEnv when OTEL_EXPORTER_OTLP_ENDPOINT is unset, a local collector over plaintext
+const (
+	defaultGRPCEndpoint = "localhost:4317"
+	defaultHTTPEndpoint = "http://localhost:4318"
+)
+
+// ConfigFromEnv creates a config from the standard OpenTelemetry environment variables. Unset variables keep the
+// defaults of Config, malformed values return an EnvConfigError naming the variable. Supported variables:
+//
+//   - OTEL_SDK_DISABLED, OTEL_SERVICE_NAME
+//   - OTEL_EXPORTER_OTLP_ENDPOINT and its _TRACES, _METRICS and _LOGS variants, OTEL_EXPORTER_OTLP_PROTOCOL,
+//     OTEL_EXPORTER_OTLP_HEADERS, OTEL_EXPORTER_OTLP_COMPRESSION, OTEL_EXPORTER_OTLP_TIMEOUT and
+//     OTEL_EXPORTER_OTLP_INSECURE
+//   - OTEL_TRACES_SAMPLER, OTEL_TRACES_SAMPLER_ARG and OTEL_PROPAGATORS
+//   - OTEL_BSP_SCHEDULE_DELAY, OTEL_BSP_EXPORT_TIMEOUT, OTEL_BSP_MAX_QUEUE_SIZE and OTEL_BSP_MAX_EXPORT_BATCH_SIZE
+//   - OTEL_METRIC_EXPORT_INTERVAL and OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE
+//   - OTEL_LOGS_EXPORTER, any value other than none enables logs
+//
+// Without OTEL_EXPORTER_OTLP_ENDPOINT the collector defaults to localhost:4317 for gRPC and http://localhost:4318 for
+// HTTP. Per-signal HTTP endpoints are used as given, without appending the /v1/<signal> path.
+//
+// Resource attributes are read from OTEL_RESOURCE_ATTRIBUTES by InitProviders
+func ConfigFromEnv() (*Config, error) {
+	var err error
+	env := envParser{}
+
+	cfg := &Config{
+		ServiceName:       os.Getenv("OTEL_SERVICE_NAME"),
+		Disabled:          env.bool("OTEL_SDK_DISABLED"),
+		Insecure:          env.bool("OTEL_EXPORTER_OTLP_INSECURE"),
+		Protocol:          os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"),
+		Compression:       os.Getenv("OTEL_EXPORTER_OTLP_COMPRESSION"),
+		ExportTimeout:     env.millis("OTEL_EXPORTER_OTLP_TIMEOUT"),
+		Headers:           env.headers("OTEL_EXPORTER_OTLP_HEADERS"),
+		MetricInterval:    env.millis("OTEL_METRIC_EXPORT_INTERVAL"),
+		MetricTemporality: strings.ToLower(os.Getenv("OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE")),
+		BatchSpan: BatchSpanConfig{
+			MaxQueueSize:       env.int("OTEL_BSP_MAX_QUEUE_SIZE"),
+			MaxExportBatchSize: env.int("OTEL_BSP_MAX_EXPORT_BATCH_SIZE"),
+			BatchTimeout:       env.millis("OTEL_BSP_SCHEDULE_DELAY"),
+			ExportTimeout:      env.millis("OTEL_BSP_EXPORT_TIMEOUT"),
+		},
+	}
+
+	if exporter, ok := os.LookupEnv(logsExporterEnv); ok && exporter != "none" {
+		cfg.EnableLogs = true
+	}
+
+	switch cfg.Protocol {
+	case "", ProtocolGRPC, ProtocolHTTP:
+	default:
+		err = errors.Join(err, EnvConfigError{"OTEL_EXPORTER_OTLP_PROTOCOL", ProtocolError{cfg.Protocol}})
+	}
+
+	var secure bool
+	cfg.OtelEndpoint, secure = envEndpoint(cfg.Protocol, otlpEndpointEnv)
+	cfg.TraceEndpoint, _ = envEndpoint(cfg.Protocol, "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	cfg.MetricEndpoint, _ = envEndpoint(cfg.Protocol, "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT")
+	cfg.LogEndpoint, _ = envEndpoint(cfg.Protocol, "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT")
+
+	if cfg.OtelEndpoint == "" {
+		cfg.OtelEndpoint = defaultGRPCEndpoint
+		if cfg.Protocol == ProtocolHTTP {
+			cfg.OtelEndpoint = defaultHTTPEndpoint
+		}
+	}
+
+	// an https endpoint verifies the collector against the system roots
+	if secure && !cfg.Insecure {
+		cfg.TlsConfig = &tls.Config{}
+	}
+
+	if propagators := os.Getenv("OTEL_PROPAGATORS"); propagators != "" {
+		for _, name := range strings.Split(propagators, ",") {
+			name = strings.TrimSpace(name)
+			if _, propagatorErr := propagatorByName(name); propagatorErr != nil {
+				err = errors.Join(err, EnvConfigError{"OTEL_PROPAGATORS", propagatorErr})
+				continue
+			}
+
+			cfg.Propagators = append(cfg.Propagators, name)
+		}
+	}
+
+	sampler, samplerErr := envSampler(os.Getenv("OTEL_TRACES_SAMPLER"), os.Getenv("OTEL_TRACES_SAMPLER_ARG"))
+	if samplerErr != nil {
+		err = errors.Join(err, samplerErr)
+	}
+	cfg.Sampler = sampler
+
+	if err = errors.Join(err, env.err); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// envEndpoint reads an endpoint variable. gRPC targets are host:port, so the scheme of a URL is dropped and reported
+// as secure when it is https. HTTP endpoints are kept as is
+func envEndpoint(protocol string, key string) (string, bool) {
+	endpoint := os.Getenv(key)
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return endpoint, false
+	}
+
+	if protocol == ProtocolHTTP {
+		return endpoint, u.Scheme == "https"
+	}
+
+	return u.Host, u.Scheme == "https"
+}
+
+// envSampler creates the sampler named by OTEL_TRACES_SAMPLER with the ratio of OTEL_TRACES_SAMPLER_ARG, or nil to
+// keep the default
+func envSampler(name string, arg string) (sdktrace.Sampler, error) {
+	ratio := 1.0
+	if arg != "" && strings.HasSuffix(name, "traceidratio") {
+		parsed, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, EnvConfigError{"OTEL_TRACES_SAMPLER_ARG", err}
+		}
+
+		if parsed < 0 || parsed > 1 {
+			return nil, EnvConfigError{"OTEL_TRACES_SAMPLER_ARG", SampleRatioError{parsed}}
+		}
+
+		ratio = parsed
+	}
+
+	switch name {
+	case "":
+		return nil, nil
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		return sdktrace.TraceIDRatioBased(ratio), nil
+	case "parentbased_always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
+	default:
+		return nil, EnvConfigError{"OTEL_TRACES_SAMPLER", SamplerError{name}}
+	}
+}
+
+// envParser parses environment variables, collecting an EnvConfigError per malformed variable
+type envParser struct {
+	err error
+}
+
+func (p *envParser) bool(key string) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return false
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		p.err = errors.Join(p.err, EnvConfigError{key, err})
+	}
+
+	return parsed
+}
+
+func (p *envParser) int(key string) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		p.err = errors.Join(p.err, EnvConfigError{key, err})
+	}
+
+	return parsed
+}
+
+// millis parses a duration given in milliseconds, the unit of the OpenTelemetry environment variables
+func (p *envParser) millis(key string) time.Duration {
+	return time.Duration(p.int(key)) * time.Millisecond
+}
+
+// headers parses a comma separated list of key=value pairs with URL encoded values
+func (p *envParser) headers(key string) map[string]string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		name, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			p.err = errors.Join(p.err, EnvConfigError{key, errors.New("missing = in " + strconv.Quote(pair))})
+			continue
+		}
+
+		decoded, err := url.QueryUnescape(strings.TrimSpace(raw))
+		if err != nil {
+			p.err = errors.Join(p.err, EnvConfigError{key, err})
+			continue
+		}
+
+		headers[strings.TrimSpace(name)] = decoded
+	}
+
+	return headers
+}
@@ -0,0 +1,138 @@
+package telemetry
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigFromEnvEndpoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		want       [3]string
+		wantSecure bool
+	}{
+		{
+			name: "defaults to the local gRPC collector",
+			want: [3]string{defaultGRPCEndpoint, "", ""},
+		},
+		{
+			name: "defaults to the local HTTP collector",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": ProtocolHTTP},
+			want: [3]string{defaultHTTPEndpoint, "", ""},
+		},
+		{
+			name:       "drops the scheme of a gRPC URL",
+			env:        map[string]string{otlpEndpointEnv: "https://collector:4317"},
+			want:       [3]string{"collector:4317", "", ""},
+			wantSecure: true,
+		},
+		{
+			name: "keeps HTTP URLs",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         ProtocolHTTP,
+				otlpEndpointEnv:                       "http://collector:4318",
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT": "http://metrics:4318/custom",
+			},
+			want: [3]string{"http://collector:4318", "", "http://metrics:4318/custom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL",
+				otlpEndpointEnv,
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+			} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg, err := ConfigFromEnv()
+			if err != nil {
+				t.Fatalf("ConfigFromEnv() error = %v", err)
+			}
+
+			got := [3]string{cfg.OtelEndpoint, cfg.TraceEndpoint, cfg.MetricEndpoint}
+			if got != tt.want {
+				t.Errorf("endpoints = %v, want %v", got, tt.want)
+			}
+
+			if secure := cfg.TlsConfig != nil; secure != tt.wantSecure {
+				t.Errorf("TlsConfig set = %v, want %v", secure, tt.wantSecure)
+			}
+		})
+	}
+}
+
+func TestConfigFromEnvHTTPSignalURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want map[signal]string
+	}{
+		{
+			name: "derives signal paths from the base endpoint",
+			env:  map[string]string{otlpEndpointEnv: "http://collector:4318/prefix"},
+			want: map[signal]string{
+				signalTraces:  "http://collector:4318/prefix/v1/traces",
+				signalMetrics: "http://collector:4318/prefix/v1/metrics",
+				signalLogs:    "http://collector:4318/prefix/v1/logs",
+			},
+		},
+		{
+			name: "uses signal endpoints as given",
+			env: map[string]string{
+				otlpEndpointEnv:                      "http://collector:4318",
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://traces:4318/ingest",
+				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT":   "http://collector:4318",
+			},
+			want: map[signal]string{
+				signalTraces:  "http://traces:4318/ingest",
+				signalMetrics: "http://collector:4318/v1/metrics",
+				signalLogs:    "http://collector:4318",
+			},
+		},
+	}
+
+	paths := map[signal]string{signalTraces: "/v1/traces", signalMetrics: "/v1/metrics", signalLogs: "/v1/logs"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", ProtocolHTTP)
+			for _, key := range []string{
+				otlpEndpointEnv,
+				"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+				"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+			} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			cfg, err := ConfigFromEnv()
+			if err != nil {
+				t.Fatalf("ConfigFromEnv() error = %v", err)
+			}
+
+			targets, err := newTargets(cfg)
+			if err != nil {
+				t.Fatalf("newTargets() error = %v", err)
+			}
+
+			got := make(map[signal]string)
+			for _, target := range targets {
+				for s, path := range paths {
+					if target.receives(s) {
+						got[s] = httpEndpointURL(target, path)
+					}
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("signal URLs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,18 @@
 package telemetry
 
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Sentinels matched by the errors of the context getters with errors.Is
+var (
+	ErrTracerMissing      = errors.New("tracer missing from context")
+	ErrMeterMissing       = errors.New("meter missing from context")
+	ErrLogProviderMissing = errors.New("logger provider missing from context")
+)
+
 type SdkResourceError struct {
 	err error
 }
@@ -8,6 +21,10 @@ func (e SdkResourceError) Error() string {
 	return "failed to create otel sdk resource: " + e.err.Error()
 }
 
+func (e SdkResourceError) Unwrap() error {
+	return e.err
+}
+
 type GrpcConnError struct {
 	err error
 }
@@ -16,12 +33,33 @@ func (e GrpcConnError) Error() string {
 	return "failed to create gRPC connection to collector: " + e.err.Error()
 }
 
+func (e GrpcConnError) Unwrap() error {
+	return e.err
+}
+
+type CollectorUnreachableError struct {
+	endpoint string
+	err      error
+}
+
+func (e CollectorUnreachableError) Error() string {
+	return "collector " + e.endpoint + " is unreachable: " + e.err.Error()
+}
+
+func (e CollectorUnreachableError) Unwrap() error {
+	return e.err
+}
+
 type TracerError struct{}
 
 func (e TracerError) Error() string {
 	return "failed to type cast tracer"
 }
 
+func (e TracerError) Is(target error) bool {
+	return target == ErrTracerMissing
+}
+
 type ResourceEnvError struct {
 	err error
 }
@@ -32,10 +70,18 @@ func (e MeterError) Error() string {
 	return "failed to type cast meter"
 }
 
+func (e MeterError) Is(target error) bool {
+	return target == ErrMeterMissing
+}
+
 func (e ResourceEnvError) Error() string {
 	return "failed to create resource from environment variables: " + e.err.Error()
 }
 
+func (e ResourceEnvError) Unwrap() error {
+	return e.err
+}
+
 type DefaultResourceError struct {
 	err error
 }
@@ -44,12 +90,67 @@ func (e DefaultResourceError) Error() string {
 	return "failed to create default resource: " + e.err.Error()
 }
 
+func (e DefaultResourceError) Unwrap() error {
+	return e.err
+}
+
+type ProvidersError struct{}
+
+func (e ProvidersError) Error() string {
+	return "failed to find providers, InitProviders has not been called"
+}
+
 type LogProviderError struct{}
 
 func (e LogProviderError) Error() string {
 	return "failed to type cast logger provider"
 }
 
+func (e LogProviderError) Is(target error) bool {
+	return target == ErrLogProviderMissing
+}
+
+type LogSeverityError struct {
+	value string
+}
+
+func (e LogSeverityError) Error() string {
+	return "unsupported minimum log severity: " + e.value
+}
+
+type ResourcePriorityError struct {
+	source ResourceSource
+}
+
+func (e ResourcePriorityError) Error() string {
+	return "invalid or repeated resource source: " + strconv.Itoa(int(e.source))
+}
+
+type SlogHandlerError struct {
+	err error
+}
+
+func (e SlogHandlerError) Error() string {
+	return "failed to create slog handler, logs must be enabled: " + e.err.Error()
+}
+
+func (e SlogHandlerError) Unwrap() error {
+	return e.err
+}
+
+type ResourceDetectorError struct {
+	detector string
+	err      error
+}
+
+func (e ResourceDetectorError) Error() string {
+	return "failed to create " + e.detector + " resource: " + e.err.Error()
+}
+
+func (e ResourceDetectorError) Unwrap() error {
+	return e.err
+}
+
 type LambdaResourceError struct {
 	err error
 }
@@ -58,6 +159,10 @@ func (e LambdaResourceError) Error() string {
 	return "failed to create lambda resource: " + e.err.Error()
 }
 
+func (e LambdaResourceError) Unwrap() error {
+	return e.err
+}
+
 type ResourceMergeError struct {
 	err error
 }
@@ -66,6 +171,10 @@ func (e ResourceMergeError) Error() string {
 	return "failed to merge lambda resource: " + e.err.Error()
 }
 
+func (e ResourceMergeError) Unwrap() error {
+	return e.err
+}
+
 type MetricExporterError struct {
 	err error
 }
@@ -74,6 +183,10 @@ func (e MetricExporterError) Error() string {
 	return "failed to create metric exporter: " + e.err.Error()
 }
 
+func (e MetricExporterError) Unwrap() error {
+	return e.err
+}
+
 type TraceExporterError struct {
 	err error
 }
@@ -82,6 +195,10 @@ func (e TraceExporterError) Error() string {
 	return "failed to create trace exporter: " + e.err.Error()
 }
 
+func (e TraceExporterError) Unwrap() error {
+	return e.err
+}
+
 type LogExporterError struct {
 	err error
 }
@@ -89,3 +206,237 @@ type LogExporterError struct {
 func (e LogExporterError) Error() string {
 	return "failed to create log exporter: " + e.err.Error()
 }
+
+func (e LogExporterError) Unwrap() error {
+	return e.err
+}
+
+type TemporalityError struct {
+	temporality string
+}
+
+func (e TemporalityError) Error() string {
+	return "unsupported metric temporality: " + e.temporality
+}
+
+type InstrumentMismatchError struct {
+	instrument InstrumentSpec
+	expected   *InstrumentSpec
+}
+
+func (e InstrumentMismatchError) Error() string {
+	if e.expected == nil {
+		return "instrument not found in manifest: " + e.instrument.Name
+	}
+
+	return "instrument " + e.instrument.Name + " does not match manifest: got " + string(e.instrument.Kind) + " (" + e.instrument.Unit + "), expected " + string(e.expected.Kind) + " (" + e.expected.Unit + ")"
+}
+
+type InstrumentMissingError struct {
+	name string
+}
+
+func (e InstrumentMissingError) Error() string {
+	return "instrument in manifest was never created: " + e.name
+}
+
+type ProfileError struct {
+	profile string
+}
+
+func (e ProfileError) Error() string {
+	return "unknown telemetry profile: " + e.profile
+}
+
+type ResourceError struct{}
+
+func (e ResourceError) Error() string {
+	return "failed to type cast resource"
+}
+
+type ResourceFileError struct {
+	err error
+}
+
+func (e ResourceFileError) Error() string {
+	return "failed to load resource file: " + e.err.Error()
+}
+
+func (e ResourceFileError) Unwrap() error {
+	return e.err
+}
+
+type AuditExportError struct {
+	err error
+}
+
+func (e AuditExportError) Error() string {
+	return "failed to export audit event: " + e.err.Error()
+}
+
+func (e AuditExportError) Unwrap() error {
+	return e.err
+}
+
+type KeyConventionError struct {
+	convention string
+}
+
+func (e KeyConventionError) Error() string {
+	return "unsupported attribute key convention: " + e.convention
+}
+
+type MeterProviderError struct{}
+
+func (e MeterProviderError) Error() string {
+	return "global meter provider is not an sdk meter provider"
+}
+
+type KPINameError struct {
+	name   string
+	prefix string
+}
+
+func (e KPINameError) Error() string {
+	return "invalid kpi name " + e.name + ": must be lowercase snake case starting with " + e.prefix
+}
+
+type KPILabelError struct {
+	name string
+	key  string
+}
+
+func (e KPILabelError) Error() string {
+	return "label " + e.key + " is not allowed for kpi " + e.name
+}
+
+type KPIDuplicateError struct {
+	name string
+}
+
+func (e KPIDuplicateError) Error() string {
+	return "kpi already registered: " + e.name
+}
+
+type ReconfigureError struct{}
+
+func (e ReconfigureError) Error() string {
+	return "telemetry providers are not initialized"
+}
+
+type TargetLayoutError struct{}
+
+func (e TargetLayoutError) Error() string {
+	return "reconfigured backends and metric routes must match the initial config"
+}
+
+type MetricRouteError struct {
+	endpoint string
+}
+
+func (e MetricRouteError) Error() string {
+	return "metric route to " + e.endpoint + " must name at least one instrument"
+}
+
+type RedactPatternError struct {
+	err error
+}
+
+func (e RedactPatternError) Error() string {
+	return "failed to compile log redaction pattern: " + e.err.Error()
+}
+
+func (e RedactPatternError) Unwrap() error {
+	return e.err
+}
+
+type ServiceNameError struct{}
+
+func (e ServiceNameError) Error() string {
+	return "service name is required"
+}
+
+type MetricIntervalError struct {
+	interval time.Duration
+}
+
+func (e MetricIntervalError) Error() string {
+	return "metric export interval must not be negative: " + e.interval.String()
+}
+
+type StdoutFormatError struct {
+	format string
+}
+
+func (e StdoutFormatError) Error() string {
+	return "unsupported stdout format: " + e.format
+}
+
+type SampleRatioError struct {
+	ratio float64
+}
+
+func (e SampleRatioError) Error() string {
+	return "sample ratio must be between 0 and 1: " + strconv.FormatFloat(e.ratio, 'g', -1, 64)
+}
+
+type DebugSampleRateError struct {
+	rate float64
+}
+
+func (e DebugSampleRateError) Error() string {
+	return "debug sample rate must be between 0 and 1: " + strconv.FormatFloat(e.rate, 'g', -1, 64)
+}
+
+type ProtocolError struct {
+	protocol string
+}
+
+func (e ProtocolError) Error() string {
+	return "unsupported otlp protocol: " + e.protocol
+}
+
+type CompressionError struct {
+	compression string
+}
+
+func (e CompressionError) Error() string {
+	return "unsupported exporter compression: " + e.compression
+}
+
+type PropagatorError struct {
+	name string
+}
+
+func (e PropagatorError) Error() string {
+	return "unsupported propagator: " + e.name
+}
+
+type EnvConfigError struct {
+	key string
+	err error
+}
+
+func (e EnvConfigError) Error() string {
+	return "failed to parse " + e.key + ": " + e.err.Error()
+}
+
+func (e EnvConfigError) Unwrap() error {
+	return e.err
+}
+
+type SamplerError struct {
+	sampler string
+}
+
+func (e SamplerError) Error() string {
+	return "unsupported sampler: " + e.sampler
+}
+
+type ExporterError struct {
+	exporter string
+}
+
+func (e ExporterError) Error() string {
+	return "unsupported exporter: " + e.exporter
+}
@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorStatusMapper maps an error to the span status recorded for it. Returning codes.Unset leaves the span status
+// untouched
+type ErrorStatusMapper func(err error) (codes.Code, string)
+
+// errorStatusMapper holds the mapper configured by InitProviders
+var errorStatusMapper atomic.Pointer[ErrorStatusMapper]
+
+// DefaultErrorStatusMapper leaves the status unset for canceled contexts, since cancellations are usually initiated
+// by the client, and reports deadlines as timeouts. Every other error is mapped to codes.Error with its message
+func DefaultErrorStatusMapper(err error) (codes.Code, string) {
+	switch {
+	case err == nil:
+		return codes.Unset, ""
+	case errors.Is(err, context.Canceled):
+		return codes.Unset, ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.Error, "timeout: " + err.Error()
+	default:
+		return codes.Error, err.Error()
+	}
+}
+
+// RecordError records the error as a span event and sets the span status from the configured ErrorStatusMapper
+func RecordError(span trace.Span, err error, opts ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err, opts...)
+	setErrorStatus(span, err)
+}
+
+// setErrorStatus sets the span status from the configured ErrorStatusMapper
+func setErrorStatus(span trace.Span, err error) {
+	if code, description := configuredErrorStatusMapper()(err); code != codes.Unset {
+		span.SetStatus(code, description)
+	}
+}
+
+// configuredErrorStatusMapper returns the ErrorStatusMapper configured by InitProviders or the default
+func configuredErrorStatusMapper() ErrorStatusMapper {
+	if configured := errorStatusMapper.Load(); configured != nil && *configured != nil {
+		return *configured
+	}
+
+	return DefaultErrorStatusMapper
+}
+
+// otherErrorType is the error.type of errors without a distinguishing type
+const otherErrorType = "_OTHER"
+
+// errorType classifies the error for the low cardinality error.type attribute. Errors the ErrorStatusMapper doesn't
+// map to codes.Error, such as canceled contexts, have no type. Deadlines are reported as timeout and other errors by
+// their outermost type that isn't a plain, fmt.Errorf or errors.Join error, or _OTHER when there is none
+func errorType(err error) string {
+	if code, _ := configuredErrorStatusMapper()(err); code != codes.Error {
+		return ""
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch typ := fmt.Sprintf("%T", err); typ {
+		case "*errors.errorString", "*errors.joinError", "*fmt.wrapError", "*fmt.wrapErrors":
+		default:
+			return typ
+		}
+	}
+
+	return otherErrorType
+}
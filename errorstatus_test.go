@@ -0,0 +1,36 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+func TestErrorType(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "canceled", err: context.Canceled, want: ""},
+		{name: "wrapped canceled", err: fmt.Errorf("query: %w", context.Canceled), want: ""},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), want: "timeout"},
+		{name: "plain", err: errors.New("failed"), want: otherErrorType},
+		{name: "joined", err: errors.Join(errors.New("a"), errors.New("b")), want: otherErrorType},
+		{name: "typed", err: pathErr, want: "*fs.PathError"},
+		{name: "wrapped typed", err: fmt.Errorf("load: %w", pathErr), want: "*fs.PathError"},
+		{name: "struct value", err: ProfileError{"fast"}, want: "telemetry.ProfileError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorType(tt.err); got != tt.want {
+				t.Errorf("errorType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,454 @@
+package telemetry
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// defaultConnectionTimeout bounds Config.WaitForConnection without a DialTimeout, matching the gRPC minimum connect
+// timeout
+const defaultConnectionTimeout = 20 * time.Second
+
+const (
+	// ExporterOTLP exports to OTLP collectors, the default
+	ExporterOTLP = "otlp"
+
+	// ExporterStdout writes telemetry to stderr for local development
+	ExporterStdout = "stdout"
+
+	// ProtocolGRPC exports over OTLP/gRPC, the default
+	ProtocolGRPC = "grpc"
+
+	// ProtocolHTTP exports over OTLP/HTTP with protobuf payloads
+	ProtocolHTTP = "http/protobuf"
+
+	// CompressionGzip compresses export payloads with gzip
+	CompressionGzip = "gzip"
+
+	// CompressionNone sends export payloads uncompressed, overriding the compression of the Profile
+	CompressionNone = "none"
+)
+
+// signal is a set of telemetry signals
+type signal uint8
+
+const (
+	signalTraces signal = 1 << iota
+	signalMetrics
+	signalLogs
+
+	allSignals = signalTraces | signalMetrics | signalLogs
+)
+
+// target is a collector that telemetry is exported to
+type target struct {
+	endpoint  string
+	conn      *grpc.ClientConn
+	tlsConfig *tls.Config
+	headers   map[string]string
+
+	// signals are the signals exported to the target, see Config.TraceEndpoint
+	signals signal
+
+	// signalURL marks an HTTP endpoint given as the URL of its signal, which is used without deriving a path
+	signalURL bool
+
+	// stdout writes to stderr instead of a collector, see ExporterStdout
+	stdout bool
+
+	// routed restricts the target to the named instruments, see MetricRoute
+	routed      bool
+	instruments []string
+}
+
+// metricsOnly reports whether the target only receives routed metrics
+func (t target) metricsOnly() bool {
+	return t.routed
+}
+
+// receives reports whether the signal is exported to the target
+func (t target) receives(s signal) bool {
+	return t.signals&s != 0
+}
+
+// newTargets creates a target for every distinct collector endpoint of the signals, every additional backend and
+// every metric route
+func newTargets(cfg *Config) ([]target, error) {
+	switch cfg.Exporter {
+	case "", ExporterOTLP:
+	case ExporterStdout:
+		return []target{{endpoint: ExporterStdout, signals: allSignals, stdout: true}}, nil
+	default:
+		return nil, ExporterError{cfg.Exporter}
+	}
+
+	switch cfg.Protocol {
+	case "", ProtocolGRPC, ProtocolHTTP:
+	default:
+		return nil, ProtocolError{cfg.Protocol}
+	}
+
+	switch cfg.Compression {
+	case "", CompressionGzip, CompressionNone:
+	default:
+		return nil, CompressionError{cfg.Compression}
+	}
+
+	if cfg.TLSSkipVerify && !cfg.Insecure {
+		slog.Warn("telemetry TLS certificate verification is disabled, do not use in production")
+	}
+
+	targets, err := newSignalTargets(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backend := range cfg.Backends {
+		target, err := newTarget(cfg, backend.Endpoint, backend.TlsConfig, backend.Headers)
+		if err != nil {
+			return nil, errors.Join(err, closeTargets(targets))
+		}
+
+		targets = append(targets, target)
+	}
+
+	for _, route := range cfg.MetricRoutes {
+		target, err := newTarget(cfg, route.Endpoint, route.TlsConfig, route.Headers)
+		if err != nil {
+			return nil, errors.Join(err, closeTargets(targets))
+		}
+
+		target.signals = signalMetrics
+		target.routed = true
+		target.instruments = route.Instruments
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// newTarget creates a target for the collector. gRPC targets get a connection that is established lazily, HTTP
+// exporters manage their own connections
+func newTarget(cfg *Config, endpoint string, tlsConfig *tls.Config, headers map[string]string) (target, error) {
+	t := target{
+		endpoint:  endpoint,
+		tlsConfig: transportTLS(cfg, tlsConfig),
+		headers:   headers,
+		signals:   allSignals,
+	}
+
+	if cfg.Protocol == ProtocolHTTP {
+		return t, nil
+	}
+
+	grpcClient, err := newGrpcClient(cfg, endpoint, t.tlsConfig)
+	if err != nil {
+		return t, GrpcConnError{err}
+	}
+
+	if cfg.WaitForConnection {
+		if err := waitForConnection(grpcClient, cmp.Or(cfg.DialTimeout, defaultConnectionTimeout)); err != nil {
+			return t, errors.Join(GrpcConnError{CollectorUnreachableError{endpoint, err}}, grpcClient.Close())
+		}
+	}
+
+	t.conn = grpcClient
+
+	return t, nil
+}
+
+// newSignalTargets creates a target for every distinct endpoint of the signals, falling back to Config.OtelEndpoint.
+// Signals sharing an endpoint share the target and its connection
+func newSignalTargets(cfg *Config) ([]target, error) {
+	endpoints := []struct {
+		endpoint string
+		override string
+		signal   signal
+	}{
+		{cmp.Or(cfg.TraceEndpoint, cfg.OtelEndpoint), cfg.TraceEndpoint, signalTraces},
+		{cmp.Or(cfg.MetricEndpoint, cfg.OtelEndpoint), cfg.MetricEndpoint, signalMetrics},
+		{cmp.Or(cfg.LogEndpoint, cfg.OtelEndpoint), cfg.LogEndpoint, signalLogs},
+	}
+
+	var targets []target
+	for _, e := range endpoints {
+		signalURL := cfg.Protocol == ProtocolHTTP && strings.Contains(e.override, "://")
+
+		i := slices.IndexFunc(targets, func(t target) bool {
+			return t.endpoint == e.endpoint && t.signalURL == signalURL
+		})
+		if i >= 0 {
+			targets[i].signals |= e.signal
+			continue
+		}
+
+		target, err := newTarget(cfg, e.endpoint, cfg.TlsConfig, cfg.Headers)
+		if err != nil {
+			return nil, errors.Join(err, closeTargets(targets))
+		}
+
+		target.signals = e.signal
+		target.signalURL = signalURL
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// exportCompression returns the compressor of the exporters, Config.Compression taking precedence over the Profile.
+// An empty string disables compression
+func exportCompression(cfg *Config) string {
+	switch cfg.Compression {
+	case "":
+		return profiles[cfg.Profile].compression
+	case CompressionNone:
+		return ""
+	default:
+		return cfg.Compression
+	}
+}
+
+// waitForConnection connects eagerly and waits until the connection is ready or the timeout expires
+func waitForConnection(conn *grpc.ClientConn, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return ctx.Err()
+		}
+	}
+}
+
+// transportTLS returns the TLS config of a collector connection, or nil for plaintext when Config.Insecure is set or
+// no TLS config is given
+func transportTLS(cfg *Config, tlsConfig *tls.Config) *tls.Config {
+	if cfg.Insecure {
+		return nil
+	}
+
+	if cfg.TLSSkipVerify {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig
+}
+
+// httpEndpointURL returns the URL of the signal path, e.g. /v1/traces, for an endpoint given as host:port, a base URL
+// or the URL of any signal, so a single endpoint serves every signal. The URL of a signal endpoint is returned as is
+func httpEndpointURL(t target, signalPath string) string {
+	if t.signalURL {
+		return t.endpoint
+	}
+
+	endpoint := t.endpoint
+	if !strings.Contains(endpoint, "://") {
+		scheme := "https"
+		if t.tlsConfig == nil {
+			scheme = "http"
+		}
+
+		endpoint = scheme + "://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	for _, signal := range []string{"/v1/traces", "/v1/metrics", "/v1/logs"} {
+		path = strings.TrimSuffix(path, signal)
+	}
+	u.Path = path + signalPath
+
+	return u.String()
+}
+
+// closeTargets closes the connection of every target
+func closeTargets(targets []target) error {
+	var err error
+	for _, target := range targets {
+		if target.conn != nil {
+			err = errors.Join(err, target.conn.Close())
+		}
+	}
+
+	return err
+}
+
+// newSpanExporter creates an OTLP span exporter for the target over its configured protocol
+func newSpanExporter(ctx context.Context, cfg *Config, target target) (sdktrace.SpanExporter, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithGRPCConn(target.conn)}
+	if len(target.headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(target.headers))
+	}
+	if cfg.ExportTimeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(cfg.ExportTimeout))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig(cfg.Retry.exportRetry())))
+	}
+	if compression := exportCompression(cfg); compression != "" {
+		opts = append(opts, otlptracegrpc.WithCompressor(compression))
+	}
+
+	normalize, err := keyNormalizer(cfg.NormalizeAttributeKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter sdktrace.SpanExporter
+	switch {
+	case target.stdout:
+		exporter, err = newStdoutSpanExporter(cfg.StdoutFormat, os.Stderr)
+	case target.conn != nil:
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		exporter, err = newHTTPSpanExporter(ctx, cfg, target)
+	}
+	if err != nil {
+		return nil, TraceExporterError{err}
+	}
+
+	if cfg.EnableSelfMetrics {
+		exporter = selfMetricsSpanExporter{exporter, newExporterMetrics("otel.sdk.exporter.span.exported", "{span}", target.endpoint)}
+	}
+
+	if normalize != nil {
+		exporter = normalizeSpanExporter{exporter, normalize}
+	}
+
+	if cfg.CircuitBreaker != nil {
+		exporter = &breakerSpanExporter{exporter, newCircuitBreaker(cfg.CircuitBreaker)}
+	}
+
+	return exporter, nil
+}
+
+// newMetricExporter creates an OTLP metric exporter for the target over its configured protocol
+func newMetricExporter(ctx context.Context, cfg *Config, target target) (sdkmetric.Exporter, error) {
+	temporality, err := temporalitySelector(cfg.MetricTemporality)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithGRPCConn(target.conn),
+		otlpmetricgrpc.WithTemporalitySelector(temporality),
+	}
+	if len(target.headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(target.headers))
+	}
+	if cfg.ExportTimeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(cfg.ExportTimeout))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig(cfg.Retry.exportRetry())))
+	}
+	if compression := exportCompression(cfg); compression != "" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(compression))
+	}
+
+	normalize, err := keyNormalizer(cfg.NormalizeAttributeKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter sdkmetric.Exporter
+	switch {
+	case target.stdout:
+		exporter, err = newStdoutMetricExporter(cfg.StdoutFormat, os.Stderr, temporality)
+	case target.conn != nil:
+		exporter, err = otlpmetricgrpc.New(ctx, opts...)
+	default:
+		exporter, err = newHTTPMetricExporter(ctx, cfg, target, temporality)
+	}
+	if err != nil {
+		return nil, MetricExporterError{err}
+	}
+
+	if cfg.EnableSelfMetrics {
+		exporter = selfMetricsMetricExporter{exporter, newExporterMetrics("otel.sdk.exporter.metric_data_point.exported", "{data_point}", target.endpoint)}
+	}
+
+	if normalize != nil {
+		exporter = normalizeMetricExporter{exporter, normalize}
+	}
+
+	if cfg.CircuitBreaker != nil {
+		exporter = &breakerMetricExporter{exporter, newCircuitBreaker(cfg.CircuitBreaker)}
+	}
+
+	return exporter, nil
+}
+
+// newLogExporter creates an OTLP log exporter for the target over its configured protocol
+func newLogExporter(ctx context.Context, cfg *Config, target target) (sdklog.Exporter, error) {
+	opts := []otlploggrpc.Option{otlploggrpc.WithGRPCConn(target.conn)}
+	if len(target.headers) > 0 {
+		opts = append(opts, otlploggrpc.WithHeaders(target.headers))
+	}
+	if cfg.ExportTimeout > 0 {
+		opts = append(opts, otlploggrpc.WithTimeout(cfg.ExportTimeout))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, otlploggrpc.WithRetry(otlploggrpc.RetryConfig(cfg.Retry.exportRetry())))
+	}
+	if compression := exportCompression(cfg); compression != "" {
+		opts = append(opts, otlploggrpc.WithCompressor(compression))
+	}
+
+	var exporter sdklog.Exporter
+	var err error
+	switch {
+	case target.stdout:
+		exporter, err = newStdoutLogExporter(cfg.StdoutFormat, os.Stderr)
+	case target.conn != nil:
+		exporter, err = otlploggrpc.New(ctx, opts...)
+	default:
+		exporter, err = newHTTPLogExporter(ctx, cfg, target)
+	}
+	if err != nil {
+		return nil, LogExporterError{err}
+	}
+
+	if cfg.EnableSelfMetrics {
+		exporter = selfMetricsLogExporter{exporter, newExporterMetrics("otel.sdk.exporter.log.exported", "{log_record}", target.endpoint)}
+	}
+
+	if cfg.CircuitBreaker != nil {
+		exporter = &breakerLogExporter{exporter, newCircuitBreaker(cfg.CircuitBreaker)}
+	}
+
+	return exporter, nil
+}
@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newHTTPSpanExporter creates an OTLP/HTTP span exporter for the target
+func newHTTPSpanExporter(ctx context.Context, cfg *Config, target target) (sdktrace.SpanExporter, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(httpEndpointURL(target, "/v1/traces"))}
+	if target.tlsConfig != nil {
+		opts = append(opts, otlptracehttp.WithTLSClientConfig(target.tlsConfig))
+	}
+	if len(target.headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(target.headers))
+	}
+	if cfg.ExportTimeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(cfg.ExportTimeout))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, otlptracehttp.WithRetry(otlptracehttp.RetryConfig(cfg.Retry.exportRetry())))
+	}
+	if exportCompression(cfg) == CompressionGzip {
+		opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+
+	return otlptracehttp.New(ctx, opts...)
+}
+
+// newHTTPMetricExporter creates an OTLP/HTTP metric exporter for the target
+func newHTTPMetricExporter(ctx context.Context, cfg *Config, target target, temporality sdkmetric.TemporalitySelector) (sdkmetric.Exporter, error) {
+	opts := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpointURL(httpEndpointURL(target, "/v1/metrics")),
+		otlpmetrichttp.WithTemporalitySelector(temporality),
+	}
+	if target.tlsConfig != nil {
+		opts = append(opts, otlpmetrichttp.WithTLSClientConfig(target.tlsConfig))
+	}
+	if len(target.headers) > 0 {
+		opts = append(opts, otlpmetrichttp.WithHeaders(target.headers))
+	}
+	if cfg.ExportTimeout > 0 {
+		opts = append(opts, otlpmetrichttp.WithTimeout(cfg.ExportTimeout))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig(cfg.Retry.exportRetry())))
+	}
+	if exportCompression(cfg) == CompressionGzip {
+		opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+
+	return otlpmetrichttp.New(ctx, opts...)
+}
+
+// newHTTPLogExporter creates an OTLP/HTTP log exporter for the target
+func newHTTPLogExporter(ctx context.Context, cfg *Config, target target) (sdklog.Exporter, error) {
+	opts := []otlploghttp.Option{otlploghttp.WithEndpointURL(httpEndpointURL(target, "/v1/logs"))}
+	if target.tlsConfig != nil {
+		opts = append(opts, otlploghttp.WithTLSClientConfig(target.tlsConfig))
+	}
+	if len(target.headers) > 0 {
+		opts = append(opts, otlploghttp.WithHeaders(target.headers))
+	}
+	if cfg.ExportTimeout > 0 {
+		opts = append(opts, otlploghttp.WithTimeout(cfg.ExportTimeout))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, otlploghttp.WithRetry(otlploghttp.RetryConfig(cfg.Retry.exportRetry())))
+	}
+	if exportCompression(cfg) == CompressionGzip {
+		opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+
+	return otlploghttp.New(ctx, opts...)
+}
@@ -0,0 +1,133 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// FanOut configures how telemetry is exported to the primary collector and Config.Backends. By default every
+// collector has its own batch and export loop, so exports run concurrently and a failing backend never delays the
+// others
+type FanOut struct {
+	// Sequential exports each batch to the collectors one after another in configuration order, the primary collector
+	// first. This uses a single batch for every collector at the cost of exports waiting on the slowest collector
+	Sequential bool
+
+	// StopOnError stops a sequential export at the first collector that fails, so collectors later in the order only
+	// receive telemetry the earlier ones accepted. By default the failure is reported and the remaining collectors are
+	// still exported to
+	StopOnError bool
+}
+
+// sequentialSpanExporter exports spans to the exporters in order and owns them
+type sequentialSpanExporter struct {
+	exporters   []sdktrace.SpanExporter
+	stopOnError bool
+}
+
+func (e sequentialSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var err error
+	for _, exporter := range e.exporters {
+		if exportErr := exporter.ExportSpans(ctx, spans); exportErr != nil {
+			err = errors.Join(err, exportErr)
+			if e.stopOnError {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+func (e sequentialSpanExporter) Shutdown(ctx context.Context) error {
+	var err error
+	for _, exporter := range e.exporters {
+		err = errors.Join(err, exporter.Shutdown(ctx))
+	}
+
+	return err
+}
+
+// sequentialMetricExporter exports metrics to the exporters in order and owns them. Temporality and aggregation are
+// taken from the first exporter, every exporter is created with the same selectors
+type sequentialMetricExporter struct {
+	sdkmetric.Exporter
+
+	exporters   []sdkmetric.Exporter
+	stopOnError bool
+}
+
+func (e sequentialMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	var err error
+	for _, exporter := range e.exporters {
+		if exportErr := exporter.Export(ctx, rm); exportErr != nil {
+			err = errors.Join(err, exportErr)
+			if e.stopOnError {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+func (e sequentialMetricExporter) ForceFlush(ctx context.Context) error {
+	var err error
+	for _, exporter := range e.exporters {
+		err = errors.Join(err, exporter.ForceFlush(ctx))
+	}
+
+	return err
+}
+
+func (e sequentialMetricExporter) Shutdown(ctx context.Context) error {
+	var err error
+	for _, exporter := range e.exporters {
+		err = errors.Join(err, exporter.Shutdown(ctx))
+	}
+
+	return err
+}
+
+// sequentialLogExporter exports log records to the exporters in order and owns them
+type sequentialLogExporter struct {
+	exporters   []sdklog.Exporter
+	stopOnError bool
+}
+
+func (e sequentialLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	var err error
+	for _, exporter := range e.exporters {
+		if exportErr := exporter.Export(ctx, records); exportErr != nil {
+			err = errors.Join(err, exportErr)
+			if e.stopOnError {
+				return err
+			}
+		}
+	}
+
+	return err
+}
+
+func (e sequentialLogExporter) ForceFlush(ctx context.Context) error {
+	var err error
+	for _, exporter := range e.exporters {
+		err = errors.Join(err, exporter.ForceFlush(ctx))
+	}
+
+	return err
+}
+
+func (e sequentialLogExporter) Shutdown(ctx context.Context) error {
+	var err error
+	for _, exporter := range e.exporters {
+		err = errors.Join(err, exporter.Shutdown(ctx))
+	}
+
+	return err
+}
@@ -0,0 +1,22 @@
+package telemetry
+
+import (
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecordGRPCStatus records the gRPC status code of the error as rpc.grpc.status_code and sets the span status. A nil
+// error or an OK code leaves the status unset, any other code sets an error status with the status message. Errors
+// that are not gRPC statuses are recorded as Unknown
+func RecordGRPCStatus(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(s.Code())))
+
+	if s.Code() != codes.OK {
+		span.SetStatus(otelcodes.Error, s.Message())
+	}
+}
@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// Handles carries the tracer, meter and logger provider resolved from a context once. Hot paths can pass it down
+// instead of calling TracerFromContext or MeterFromContext, which walk the context chain on every call
+type Handles struct {
+	Tracer         trace.Tracer
+	Meter          metric.Meter
+	LoggerProvider *sdklog.LoggerProvider
+}
+
+// HandlesFromContext resolves the tracer, meter and logger provider in the context. Missing values are left nil
+func HandlesFromContext(ctx context.Context) Handles {
+	var handles Handles
+	handles.Tracer, _ = ctx.Value(TracerCtxKey{}).(trace.Tracer)
+	handles.Meter, _ = ctx.Value(MeterCtxKey{}).(metric.Meter)
+	handles.LoggerProvider, _ = ctx.Value(LoggerCtxKey{}).(*sdklog.LoggerProvider)
+
+	return handles
+}
+
+// Context adds the handles to the context so the regular context helpers can find them
+func (h Handles) Context(ctx context.Context) context.Context {
+	if h.Tracer != nil {
+		ctx = context.WithValue(ctx, TracerCtxKey{}, h.Tracer)
+	}
+
+	if h.Meter != nil {
+		ctx = context.WithValue(ctx, MeterCtxKey{}, h.Meter)
+	}
+
+	if h.LoggerProvider != nil {
+		ctx = context.WithValue(ctx, LoggerCtxKey{}, h.LoggerProvider)
+	}
+
+	return ctx
+}
@@ -0,0 +1,58 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+type benchmarkCtxKey struct{ depth int }
+
+// benchmarkContext stores the tracer and meter beneath several unrelated values, as request middleware would
+func benchmarkContext() context.Context {
+	ctx := AddTracerContext(context.Background(), tracenoop.NewTracerProvider().Tracer("bench"))
+	ctx = AddMeterContext(ctx, noop.NewMeterProvider().Meter("bench"))
+
+	for depth := range 16 {
+		ctx = context.WithValue(ctx, benchmarkCtxKey{depth}, depth)
+	}
+
+	return ctx
+}
+
+func BenchmarkContextLookup(b *testing.B) {
+	ctx := benchmarkContext()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		tracer, err := TracerFromContext(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		meter, err := MeterFromContext(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		_, _ = tracer, meter
+	}
+}
+
+func BenchmarkHandles(b *testing.B) {
+	handles := HandlesFromContext(benchmarkContext())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		tracer, meter := handles.Tracer, handles.Meter
+		if tracer == nil || meter == nil {
+			b.Fatal("handles missing tracer or meter")
+		}
+	}
+}
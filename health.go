@@ -0,0 +1,117 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"google.golang.org/grpc/connectivity"
+)
+
+const collectorUpMetric = "telemetry_collector_up"
+
+// collectorWatcher tracks the connection state of the current targets for the telemetry_collector_up gauge
+type collectorWatcher struct {
+	ctx context.Context
+
+	mu          sync.Mutex
+	cancel      context.CancelFunc
+	connections []watchedConnection
+}
+
+// watchedConnection is the latest state of a target connection
+type watchedConnection struct {
+	attrs metric.ObserveOption
+	up    *atomic.Int64
+}
+
+// watchCollectors registers the telemetry_collector_up gauge, reporting 1 while the connection to a collector is
+// ready and 0 otherwise. Connection states are tracked until the context is canceled, and the watcher follows the
+// targets of a Reconfigure. HTTP targets have no persistent connection and are not reported
+func watchCollectors(ctx context.Context, meter metric.Meter, targets []target) (*collectorWatcher, error) {
+	watcher := &collectorWatcher{ctx: ctx}
+	watcher.watch(targets)
+
+	_, err := meter.Int64ObservableGauge(collectorUpMetric,
+		metric.WithDescription("Whether the connection to the telemetry collector is ready"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			watcher.mu.Lock()
+			defer watcher.mu.Unlock()
+
+			for _, connection := range watcher.connections {
+				observer.Observe(connection.up.Load(), connection.attrs)
+			}
+
+			return nil
+		}),
+	)
+	if err != nil {
+		watcher.stop()
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+// watch replaces the watched connections with the connections of the targets. A nil watcher does nothing
+func (w *collectorWatcher) watch(targets []target) {
+	if w == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(w.ctx)
+
+	var connections []watchedConnection
+	for _, target := range targets {
+		if target.conn == nil {
+			continue
+		}
+
+		connection := watchedConnection{
+			attrs: metric.WithAttributes(semconv.ServerAddress(target.endpoint)),
+			up:    new(atomic.Int64),
+		}
+		connections = append(connections, connection)
+
+		target.conn.Connect()
+		go watchConnection(ctx, target, connection.up)
+	}
+
+	w.mu.Lock()
+	previous := w.cancel
+	w.cancel = cancel
+	w.connections = connections
+	w.mu.Unlock()
+
+	if previous != nil {
+		previous()
+	}
+}
+
+// stop stops watching the connections
+func (w *collectorWatcher) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		w.cancel()
+	}
+}
+
+// watchConnection stores 1 in up while the target connection is ready and 0 otherwise
+func watchConnection(ctx context.Context, target target, up *atomic.Int64) {
+	for {
+		state := target.conn.GetState()
+		if state == connectivity.Ready {
+			up.Store(1)
+		} else {
+			up.Store(0)
+		}
+
+		if !target.conn.WaitForStateChange(ctx, state) {
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package telemetry
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// startCollector serves gRPC on a local port until the test ends and returns its address
+func startCollector(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+// collectorUp collects the telemetry_collector_up gauge by server.address
+func collectorUp(t *testing.T, reader sdkmetric.Reader) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	up := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			gauge, ok := m.Data.(metricdata.Gauge[int64])
+			if m.Name != collectorUpMetric || !ok {
+				continue
+			}
+
+			for _, dp := range gauge.DataPoints {
+				address, _ := dp.Attributes.Value(semconv.ServerAddressKey)
+				up[address.AsString()] = dp.Value
+			}
+		}
+	}
+
+	return up
+}
+
+// waitForCollectorUp collects the gauge until it reports the want states or the timeout passes
+func waitForCollectorUp(t *testing.T, reader sdkmetric.Reader, want map[string]int64) {
+	t.Helper()
+
+	var got map[string]int64
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		got = collectorUp(t, reader)
+		if len(got) == len(want) && equalStates(got, want) {
+			return
+		}
+	}
+
+	t.Fatalf("%s = %v, want %v", collectorUpMetric, got, want)
+}
+
+func equalStates(got, want map[string]int64) bool {
+	for address, state := range want {
+		if got[address] != state {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestWatchCollectorsFollowsTargets(t *testing.T) {
+	first, second := startCollector(t), startCollector(t)
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer provider.Shutdown(context.Background())
+
+	targets, err := newTargets(&Config{OtelEndpoint: first, Insecure: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := watchCollectors(context.Background(), provider.Meter("test"), targets)
+	if err != nil {
+		t.Fatalf("watchCollectors() error = %v", err)
+	}
+	defer watcher.stop()
+
+	waitForCollectorUp(t, reader, map[string]int64{first: 1})
+
+	next, err := newTargets(&Config{OtelEndpoint: second, Insecure: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeTargets(next)
+
+	watcher.watch(next)
+	if err := closeTargets(targets); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForCollectorUp(t, reader, map[string]int64{second: 1})
+}
@@ -0,0 +1,195 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPOption configures the HTTP middleware
+type HTTPOption func(*httpConfig)
+
+type httpConfig struct {
+	routeTemplates bool
+}
+
+// WithRouteTemplates names server spans after the matched http.ServeMux pattern, e.g. "GET /users/{id}", and records
+// it as http.route. Off by default since the pattern is only known for requests routed by a ServeMux
+func WithRouteTemplates() HTTPOption {
+	return func(cfg *httpConfig) {
+		cfg.routeTemplates = true
+	}
+}
+
+// HTTPMiddleware traces requests with server spans using the tracer in the context, continuing upstream traces from
+// the global propagator. Spans carry the stable HTTP semantic convention attributes http.request.method,
+// http.response.status_code, url.path, url.scheme, server.address and server.port
+func HTTPMiddleware(ctx context.Context, next http.Handler, opts ...HTTPOption) http.Handler {
+	var cfg httpConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer, err := TracerFromContext(ctx)
+	if err != nil {
+		tracer = globalTracer()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := ExtractHTTP(r.Context(), r.Header)
+
+		method, methodAttrs := httpMethod(r.Method)
+		ctx, span := tracer.Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(httpServerAttributes(r, methodAttrs)...),
+		)
+		defer span.End()
+
+		// the ServeMux records the matched pattern on the request it receives
+		req := r.WithContext(ctx)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder.writer(), req)
+
+		if cfg.routeTemplates && req.Pattern != "" {
+			route := req.Pattern
+			if !strings.Contains(route, " ") {
+				route = method + " " + route
+			}
+
+			span.SetName(route)
+			span.SetAttributes(semconv.HTTPRoute(route[strings.Index(route, " ")+1:]))
+		}
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+	})
+}
+
+// httpMethod returns the span name and method attributes, mapping nonstandard methods to _OTHER
+func httpMethod(method string) (string, []attribute.KeyValue) {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method, []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(method)}
+	default:
+		return "HTTP", []attribute.KeyValue{semconv.HTTPRequestMethodOther, semconv.HTTPRequestMethodOriginal(method)}
+	}
+}
+
+// httpServerAttributes returns the stable semantic convention attributes of a server request
+func httpServerAttributes(r *http.Request, attrs []attribute.KeyValue) []attribute.KeyValue {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	attrs = append(attrs,
+		semconv.URLPath(r.URL.Path),
+		semconv.URLScheme(scheme),
+	)
+
+	host, port, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+
+	if host != "" {
+		attrs = append(attrs, semconv.ServerAddress(host))
+	}
+
+	if p, err := strconv.Atoi(port); err == nil {
+		attrs = append(attrs, semconv.ServerPort(p))
+	}
+
+	return attrs
+}
+
+// statusRecorder captures the response status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// writer returns the recorder with the http.Flusher and http.Hijacker methods of the wrapped writer, so handlers
+// asserting them for streaming responses or websockets keep working
+func (r *statusRecorder) writer() http.ResponseWriter {
+	_, flusher := r.ResponseWriter.(http.Flusher)
+	_, hijacker := r.ResponseWriter.(http.Hijacker)
+
+	switch {
+	case flusher && hijacker:
+		return flushHijackRecorder{r}
+	case flusher:
+		return flushRecorder{r}
+	case hijacker:
+		return hijackRecorder{r}
+	default:
+		return r
+	}
+}
+
+func (r *statusRecorder) flush() {
+	// flushing sends the implicit 200 status, later calls to WriteHeader are ignored
+	r.wroteHeader = true
+	r.ResponseWriter.(http.Flusher).Flush()
+}
+
+func (r *statusRecorder) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+// flushRecorder is a statusRecorder of a writer implementing http.Flusher
+type flushRecorder struct {
+	*statusRecorder
+}
+
+func (r flushRecorder) Flush() {
+	r.flush()
+}
+
+// hijackRecorder is a statusRecorder of a writer implementing http.Hijacker
+type hijackRecorder struct {
+	*statusRecorder
+}
+
+func (r hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.hijack()
+}
+
+// flushHijackRecorder is a statusRecorder of a writer implementing http.Flusher and http.Hijacker
+type flushHijackRecorder struct {
+	*statusRecorder
+}
+
+func (r flushHijackRecorder) Flush() {
+	r.flush()
+}
+
+func (r flushHijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return r.hijack()
+}
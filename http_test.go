@@ -0,0 +1,99 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// hijackWriter is a response writer that implements http.Hijacker but not http.Flusher
+type hijackWriter struct {
+	http.ResponseWriter
+}
+
+func (w hijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+// flushHijackWriter is a response writer that implements http.Flusher and http.Hijacker
+type flushHijackWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w flushHijackWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+func TestHTTPMiddlewareWriterInterfaces(t *testing.T) {
+	tests := []struct {
+		name        string
+		writer      func() http.ResponseWriter
+		wantFlusher bool
+		wantHijack  bool
+	}{
+		{name: "flusher", writer: func() http.ResponseWriter { return httptest.NewRecorder() }, wantFlusher: true},
+		{name: "hijacker", writer: func() http.ResponseWriter { return hijackWriter{httptest.NewRecorder()} }, wantHijack: true},
+		{name: "flusher and hijacker", writer: func() http.ResponseWriter { return flushHijackWriter{httptest.NewRecorder()} }, wantFlusher: true, wantHijack: true},
+		{name: "neither", writer: func() http.ResponseWriter { return struct{ http.ResponseWriter }{httptest.NewRecorder()} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer provider.Shutdown(context.Background())
+
+			ctx := AddTracerContext(context.Background(), provider.Tracer("test"))
+
+			handler := HTTPMiddleware(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				flusher, isFlusher := w.(http.Flusher)
+				if isFlusher != tt.wantFlusher {
+					t.Errorf("writer implements http.Flusher = %v, want %v", isFlusher, tt.wantFlusher)
+				}
+
+				hijacker, isHijacker := w.(http.Hijacker)
+				if isHijacker != tt.wantHijack {
+					t.Errorf("writer implements http.Hijacker = %v, want %v", isHijacker, tt.wantHijack)
+				}
+
+				if isHijacker {
+					if _, _, err := hijacker.Hijack(); err != nil {
+						t.Errorf("Hijack() error = %v", err)
+					}
+				}
+
+				if isFlusher {
+					flusher.Flush()
+				}
+
+				// ignored after a flush, which sends the implicit 200 status
+				w.WriteHeader(http.StatusAccepted)
+			}))
+
+			handler.ServeHTTP(tt.writer(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("exported %d spans, want 1", len(spans))
+			}
+
+			want := http.StatusAccepted
+			if tt.wantFlusher {
+				want = http.StatusOK
+			}
+
+			for _, attr := range spans[0].Attributes {
+				if attr.Key == semconv.HTTPResponseStatusCodeKey && attr.Value.AsInt64() != int64(want) {
+					t.Errorf("status code = %d, want %d", attr.Value.AsInt64(), want)
+				}
+			}
+		})
+	}
+}
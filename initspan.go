@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const initSpanName = "telemetry.init"
+
+// initTimings measures the phases of InitProviders so they can be reported once the trace pipeline is up
+type initTimings struct {
+	start  time.Time
+	last   time.Time
+	phases []attribute.KeyValue
+}
+
+func newInitTimings() *initTimings {
+	now := time.Now()
+
+	return &initTimings{
+		start: now,
+		last:  now,
+	}
+}
+
+// phase records the time since the previous phase as a telemetry.init.<name>_ms attribute
+func (t *initTimings) phase(name string) {
+	now := time.Now()
+	t.phases = append(t.phases, attribute.Float64(
+		initSpanName+"."+name+"_ms",
+		float64(now.Sub(t.last))/float64(time.Millisecond),
+	))
+	t.last = now
+}
+
+// record emits a span covering the whole initialization, backdated to its start
+func (t *initTimings) record(ctx context.Context, tracer trace.Tracer) {
+	_, span := tracer.Start(ctx, initSpanName,
+		trace.WithTimestamp(t.start),
+		trace.WithAttributes(t.phases...),
+	)
+	span.End()
+}
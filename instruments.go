@@ -0,0 +1,240 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// InstrumentKind identifies the type of a metric instrument
+type InstrumentKind string
+
+const (
+	InstrumentCounter                 InstrumentKind = "counter"
+	InstrumentUpDownCounter           InstrumentKind = "updowncounter"
+	InstrumentHistogram               InstrumentKind = "histogram"
+	InstrumentGauge                   InstrumentKind = "gauge"
+	InstrumentObservableCounter       InstrumentKind = "observable_counter"
+	InstrumentObservableUpDownCounter InstrumentKind = "observable_updowncounter"
+	InstrumentObservableGauge         InstrumentKind = "observable_gauge"
+)
+
+// InstrumentSpec describes a metric instrument by name, unit and kind
+type InstrumentSpec struct {
+	Name string
+	Unit string
+	Kind InstrumentKind
+}
+
+// RegisteredInstruments returns the instruments created with the meter stored in the context, in creation order
+func RegisteredInstruments(ctx context.Context) ([]InstrumentSpec, error) {
+	meter, ok := ctx.Value(MeterCtxKey{}).(*registryMeter)
+	if !ok {
+		return nil, MeterError{}
+	}
+
+	return meter.registered(), nil
+}
+
+// ValidateInstruments checks that every instrument in Config.Instruments has been created with the meter stored
+// in the context. Instruments that do not match the manifest are rejected when they are created
+func ValidateInstruments(ctx context.Context) error {
+	meter, ok := ctx.Value(MeterCtxKey{}).(*registryMeter)
+	if !ok {
+		return MeterError{}
+	}
+
+	registered := meter.registered()
+
+	var err error
+	for _, spec := range meter.manifest {
+		if !slices.Contains(registered, spec) {
+			err = errors.Join(err, InstrumentMissingError{spec.Name})
+		}
+	}
+
+	return err
+}
+
+// registryMeter records the instruments created through it and validates them against an optional manifest
+type registryMeter struct {
+	metric.Meter
+
+	manifest []InstrumentSpec
+
+	mu          sync.Mutex
+	instruments []InstrumentSpec
+
+	// cache holds the instruments of the metric helpers by spec, so repeated measurements skip creation and
+	// registration. It lives as long as the meter provider
+	cache sync.Map
+}
+
+func newRegistryMeter(meter metric.Meter, manifest []InstrumentSpec) *registryMeter {
+	return &registryMeter{
+		Meter:    meter,
+		manifest: manifest,
+	}
+}
+
+// register records the instrument and checks it against the manifest
+func (m *registryMeter) register(spec InstrumentSpec) error {
+	m.mu.Lock()
+	if !slices.Contains(m.instruments, spec) {
+		m.instruments = append(m.instruments, spec)
+	}
+	m.mu.Unlock()
+
+	if len(m.manifest) == 0 {
+		return nil
+	}
+
+	idx := slices.IndexFunc(m.manifest, func(expected InstrumentSpec) bool {
+		return expected.Name == spec.Name
+	})
+	if idx < 0 {
+		return InstrumentMismatchError{spec, nil}
+	}
+
+	if m.manifest[idx] != spec {
+		return InstrumentMismatchError{spec, &m.manifest[idx]}
+	}
+
+	return nil
+}
+
+// cachedInstrument returns the instrument of the spec created by create, calling it once per meter. Failed creations
+// are not cached
+func cachedInstrument[T any](m *registryMeter, spec InstrumentSpec, create func() (T, error)) (T, error) {
+	if cached, ok := m.cache.Load(spec); ok {
+		return cached.(T), nil
+	}
+
+	instrument, err := create()
+	if err != nil {
+		return instrument, err
+	}
+
+	cached, _ := m.cache.LoadOrStore(spec, instrument)
+
+	return cached.(T), nil
+}
+
+// helperInt64Counter returns the named counter of the meter, cached when the meter was created by InitProviders
+func helperInt64Counter(meter metric.Meter, name string) (metric.Int64Counter, error) {
+	registry, ok := meter.(*registryMeter)
+	if !ok {
+		return meter.Int64Counter(name)
+	}
+
+	return cachedInstrument(registry, InstrumentSpec{name, "", InstrumentCounter}, func() (metric.Int64Counter, error) {
+		return registry.Int64Counter(name)
+	})
+}
+
+// helperFloat64Histogram returns the named histogram of the meter in the unit, cached when the meter was created by
+// InitProviders
+func helperFloat64Histogram(meter metric.Meter, name string, unit string) (metric.Float64Histogram, error) {
+	registry, ok := meter.(*registryMeter)
+	if !ok {
+		return meter.Float64Histogram(name, metric.WithUnit(unit))
+	}
+
+	return cachedInstrument(registry, InstrumentSpec{name, unit, InstrumentHistogram}, func() (metric.Float64Histogram, error) {
+		return registry.Float64Histogram(name, metric.WithUnit(unit))
+	})
+}
+
+func (m *registryMeter) registered() []InstrumentSpec {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.instruments)
+}
+
+func (m *registryMeter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	cfg := metric.NewInt64CounterConfig(options...)
+	instrument, err := m.Meter.Int64Counter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentCounter}))
+}
+
+func (m *registryMeter) Int64UpDownCounter(name string, options ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	cfg := metric.NewInt64UpDownCounterConfig(options...)
+	instrument, err := m.Meter.Int64UpDownCounter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentUpDownCounter}))
+}
+
+func (m *registryMeter) Int64Histogram(name string, options ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	cfg := metric.NewInt64HistogramConfig(options...)
+	instrument, err := m.Meter.Int64Histogram(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentHistogram}))
+}
+
+func (m *registryMeter) Int64Gauge(name string, options ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	cfg := metric.NewInt64GaugeConfig(options...)
+	instrument, err := m.Meter.Int64Gauge(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentGauge}))
+}
+
+func (m *registryMeter) Int64ObservableCounter(name string, options ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	cfg := metric.NewInt64ObservableCounterConfig(options...)
+	instrument, err := m.Meter.Int64ObservableCounter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentObservableCounter}))
+}
+
+func (m *registryMeter) Int64ObservableUpDownCounter(name string, options ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	cfg := metric.NewInt64ObservableUpDownCounterConfig(options...)
+	instrument, err := m.Meter.Int64ObservableUpDownCounter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentObservableUpDownCounter}))
+}
+
+func (m *registryMeter) Int64ObservableGauge(name string, options ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	cfg := metric.NewInt64ObservableGaugeConfig(options...)
+	instrument, err := m.Meter.Int64ObservableGauge(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentObservableGauge}))
+}
+
+func (m *registryMeter) Float64Counter(name string, options ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	cfg := metric.NewFloat64CounterConfig(options...)
+	instrument, err := m.Meter.Float64Counter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentCounter}))
+}
+
+func (m *registryMeter) Float64UpDownCounter(name string, options ...metric.Float64UpDownCounterOption) (metric.Float64UpDownCounter, error) {
+	cfg := metric.NewFloat64UpDownCounterConfig(options...)
+	instrument, err := m.Meter.Float64UpDownCounter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentUpDownCounter}))
+}
+
+func (m *registryMeter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	cfg := metric.NewFloat64HistogramConfig(options...)
+	instrument, err := m.Meter.Float64Histogram(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentHistogram}))
+}
+
+func (m *registryMeter) Float64Gauge(name string, options ...metric.Float64GaugeOption) (metric.Float64Gauge, error) {
+	cfg := metric.NewFloat64GaugeConfig(options...)
+	instrument, err := m.Meter.Float64Gauge(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentGauge}))
+}
+
+func (m *registryMeter) Float64ObservableCounter(name string, options ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	cfg := metric.NewFloat64ObservableCounterConfig(options...)
+	instrument, err := m.Meter.Float64ObservableCounter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentObservableCounter}))
+}
+
+func (m *registryMeter) Float64ObservableUpDownCounter(name string, options ...metric.Float64ObservableUpDownCounterOption) (metric.Float64ObservableUpDownCounter, error) {
+	cfg := metric.NewFloat64ObservableUpDownCounterConfig(options...)
+	instrument, err := m.Meter.Float64ObservableUpDownCounter(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentObservableUpDownCounter}))
+}
+
+func (m *registryMeter) Float64ObservableGauge(name string, options ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	cfg := metric.NewFloat64ObservableGaugeConfig(options...)
+	instrument, err := m.Meter.Float64ObservableGauge(name, options...)
+	return instrument, errors.Join(err, m.register(InstrumentSpec{name, cfg.Unit(), InstrumentObservableGauge}))
+}
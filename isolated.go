@@ -0,0 +1,38 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewIsolatedProvider creates a trace provider with its own resource, connection and exporter for subsystems that
+// must not affect the global telemetry state. The provider is not registered globally and does not keep debug spans.
+// The returned function shuts down the provider and closes its connections
+func NewIsolatedProvider(ctx context.Context, cfg *Config) (*sdktrace.TracerProvider, func(context.Context) error, error) {
+	if _, err := lookupProfile(cfg.Profile); err != nil {
+		return nil, nil, err
+	}
+
+	resource, err := setupResource(ctx, cfg)
+	if err != nil {
+		return nil, nil, SdkResourceError{err}
+	}
+
+	targets, err := newTargets(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	traceProvider, err := newTraceProvider(ctx, cfg, targets, resource, nil)
+	if err != nil {
+		return nil, nil, errors.Join(err, closeTargets(targets))
+	}
+
+	shutdown := func(ctx context.Context) error {
+		return errors.Join(traceProvider.Shutdown(ctx), closeTargets(targets))
+	}
+
+	return traceProvider, shutdown, nil
+}
@@ -0,0 +1,117 @@
+package telemetry
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// kpiName is the naming convention for business KPIs, e.g. orders_completed
+var kpiName = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// KPISpec describes a business KPI. LabelKeys are the only attribute keys it may be recorded with
+type KPISpec struct {
+	Name        string
+	Description string
+	Unit        string
+	LabelKeys   []attribute.Key
+}
+
+// KPIRegistry registers business KPIs as counters on the meter in the context, enforcing a shared name prefix and
+// the allowed label keys of every KPI. Register KPIs during startup so invalid definitions fail fast
+type KPIRegistry struct {
+	meter  metric.Meter
+	prefix string
+
+	mu   sync.Mutex
+	kpis map[string]*KPI
+}
+
+// NewKPIRegistry creates a KPI registry using the meter in the context. Every KPI name must start with the prefix
+func NewKPIRegistry(ctx context.Context, prefix string) (*KPIRegistry, error) {
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KPIRegistry{
+		meter:  meter,
+		prefix: prefix,
+		kpis:   make(map[string]*KPI),
+	}, nil
+}
+
+// Register validates the spec and creates its counter. Names must be lowercase snake case starting with the registry
+// prefix and may only be registered once
+func (r *KPIRegistry) Register(spec KPISpec) (*KPI, error) {
+	if !strings.HasPrefix(spec.Name, r.prefix) || !kpiName.MatchString(spec.Name) {
+		return nil, KPINameError{spec.Name, r.prefix}
+	}
+
+	for _, key := range spec.LabelKeys {
+		if !key.Defined() {
+			return nil, KPILabelError{spec.Name, string(key)}
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.kpis[spec.Name]; ok {
+		return nil, KPIDuplicateError{spec.Name}
+	}
+
+	opts := []metric.Float64CounterOption{metric.WithDescription(spec.Description)}
+	if spec.Unit != "" {
+		opts = append(opts, metric.WithUnit(spec.Unit))
+	}
+
+	counter, err := r.meter.Float64Counter(spec.Name, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	kpi := &KPI{
+		name:      spec.Name,
+		labelKeys: slices.Clone(spec.LabelKeys),
+		counter:   counter,
+	}
+	r.kpis[spec.Name] = kpi
+
+	return kpi, nil
+}
+
+// MustRegister is like Register but panics if the spec is invalid. It is meant for package level KPI definitions
+func (r *KPIRegistry) MustRegister(spec KPISpec) *KPI {
+	kpi, err := r.Register(spec)
+	if err != nil {
+		panic(err)
+	}
+
+	return kpi
+}
+
+// KPI is a registered business KPI
+type KPI struct {
+	name      string
+	labelKeys []attribute.Key
+	counter   metric.Float64Counter
+}
+
+// Add records the value, rejecting attributes outside the label keys of the KPI
+func (k *KPI) Add(ctx context.Context, value float64, attrs ...attribute.KeyValue) error {
+	for _, attr := range attrs {
+		if !slices.Contains(k.labelKeys, attr.Key) {
+			return KPILabelError{k.name, string(attr.Key)}
+		}
+	}
+
+	k.counter.Add(ctx, value, metric.WithAttributes(attrs...))
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestKPIRegistryRegister(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    KPISpec
+		wantErr error
+	}{
+		{
+			name: "valid kpi",
+			spec: KPISpec{Name: "shop_orders_completed", LabelKeys: []attribute.Key{"region"}},
+		},
+		{
+			name:    "missing prefix",
+			spec:    KPISpec{Name: "orders_completed"},
+			wantErr: KPINameError{"orders_completed", "shop_"},
+		},
+		{
+			name:    "not snake case",
+			spec:    KPISpec{Name: "shop_ordersCompleted"},
+			wantErr: KPINameError{"shop_ordersCompleted", "shop_"},
+		},
+		{
+			name:    "undefined label key",
+			spec:    KPISpec{Name: "shop_orders_failed", LabelKeys: []attribute.Key{""}},
+			wantErr: KPILabelError{"shop_orders_failed", ""},
+		},
+		{
+			name:    "duplicate",
+			spec:    KPISpec{Name: "shop_revenue"},
+			wantErr: KPIDuplicateError{"shop_revenue"},
+		},
+	}
+
+	provider := sdkmetric.NewMeterProvider()
+	defer provider.Shutdown(context.Background())
+
+	registry, err := NewKPIRegistry(AddMeterContext(context.Background(), provider.Meter("test")), "shop_")
+	if err != nil {
+		t.Fatalf("NewKPIRegistry() error = %v", err)
+	}
+
+	if _, err := registry.Register(KPISpec{Name: "shop_revenue"}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kpi, err := registry.Register(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if (kpi != nil) != (tt.wantErr == nil) {
+				t.Errorf("Register() kpi = %v, want a kpi only without error", kpi)
+			}
+		})
+	}
+}
+
+func TestKPIRegistryMissingMeter(t *testing.T) {
+	if _, err := NewKPIRegistry(context.Background(), "shop_"); !errors.Is(err, ErrMeterMissing) {
+		t.Errorf("NewKPIRegistry() error = %v, want %v", err, ErrMeterMissing)
+	}
+}
+
+func TestKPIAdd(t *testing.T) {
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer provider.Shutdown(ctx)
+
+	registry, err := NewKPIRegistry(AddMeterContext(ctx, provider.Meter("test")), "shop_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kpi := registry.MustRegister(KPISpec{Name: "shop_revenue", Unit: "EUR", LabelKeys: []attribute.Key{"region"}})
+
+	if err := kpi.Add(ctx, 2.5, attribute.String("region", "eu")); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := kpi.Add(ctx, 1, attribute.String("customer", "42")); !errors.Is(err, KPILabelError{"shop_revenue", "customer"}) {
+		t.Errorf("Add() error = %v, want a KPILabelError", err)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rm.ScopeMetrics) != 1 || len(rm.ScopeMetrics[0].Metrics) != 1 {
+		t.Fatalf("metrics = %+v, want shop_revenue", rm.ScopeMetrics)
+	}
+
+	m := rm.ScopeMetrics[0].Metrics[0]
+	sum, ok := m.Data.(metricdata.Sum[float64])
+	if m.Name != "shop_revenue" || m.Unit != "EUR" || !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 2.5 {
+		t.Errorf("shop_revenue = %+v, want a single data point of 2.5 EUR", m)
+	}
+}
+
+func TestKPIRegistryMustRegisterPanics(t *testing.T) {
+	provider := sdkmetric.NewMeterProvider()
+	defer provider.Shutdown(context.Background())
+
+	registry, err := NewKPIRegistry(AddMeterContext(context.Background(), provider.Meter("test")), "shop_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegister() did not panic on an invalid name")
+		}
+	}()
+
+	registry.MustRegister(KPISpec{Name: "invalid"})
+}
@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/log"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// traceSampledProcessor drops log records that belong to an unsampled trace before they reach the wrapped processor.
+// Records emitted outside of a trace are always processed
+type traceSampledProcessor struct {
+	sdklog.Processor
+}
+
+func (p traceSampledProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if record.TraceID().IsValid() && !record.TraceFlags().IsSampled() {
+		return nil
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// minSeverityProcessor drops log records below the minimum severity before they reach the wrapped processor. Records
+// without a severity are always processed
+type minSeverityProcessor struct {
+	sdklog.Processor
+
+	min log.Severity
+}
+
+func (p minSeverityProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if severity := record.Severity(); severity != log.SeverityUndefined && severity < p.min {
+		return nil
+	}
+
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// minLogSeverity returns the minimum log severity from the environment, which overrides Config.MinLogSeverity. The
+// variable accepts severity names such as WARN or error2 as well as severity numbers
+func minLogSeverity(cfg *Config) (log.Severity, error) {
+	value, ok := os.LookupEnv(minLogSeverityEnv)
+	if !ok || value == "" {
+		return cfg.MinLogSeverity, nil
+	}
+
+	if number, err := strconv.Atoi(value); err == nil && number >= int(log.SeverityTrace1) && number <= int(log.SeverityFatal4) {
+		return log.Severity(number), nil
+	}
+
+	for severity := log.SeverityTrace1; severity <= log.SeverityFatal4; severity++ {
+		if strings.EqualFold(severity.String(), value) {
+			return severity, nil
+		}
+	}
+
+	return log.SeverityUndefined, LogSeverityError{value}
+}
@@ -0,0 +1,132 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// CollectMetrics immediately collects and exports metrics from every reader of the global meter provider, outside of
+// the periodic export interval
+func CollectMetrics(ctx context.Context) error {
+	meterProvider, ok := otel.GetMeterProvider().(*sdkmetric.MeterProvider)
+	if !ok {
+		return MeterProviderError{}
+	}
+
+	return meterProvider.ForceFlush(ctx)
+}
+
+// Int64Counter creates the named counter with the meter in the context. A MeterError is returned when the context has
+// no meter
+func Int64Counter(ctx context.Context, name string, opts ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return meter.Int64Counter(name, opts...)
+}
+
+// Float64Histogram creates the named histogram with the meter in the context. A MeterError is returned when the
+// context has no meter
+func Float64Histogram(ctx context.Context, name string, opts ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return meter.Float64Histogram(name, opts...)
+}
+
+// Incr adds one to the named counter of the meter in the context. The context is passed to the measurement so the
+// active span is attached as an exemplar when exemplar collection is enabled. Requests marked with WithDebugRequest
+// also carry their request.id. The counter is created once per meter provider
+func Incr(ctx context.Context, name string, attrs ...attribute.KeyValue) error {
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	counter, err := helperInt64Counter(meter, name)
+	if err != nil {
+		return err
+	}
+
+	counter.Add(ctx, 1, metric.WithAttributes(withDebugRequestAttribute(ctx, attrs)...))
+
+	return nil
+}
+
+// Record records the value in the named histogram of the meter in the context. The context is passed to the
+// measurement so the active span is attached as an exemplar when exemplar collection is enabled. Requests marked with
+// WithDebugRequest also carry their request.id. The histogram is created once per meter provider
+func Record(ctx context.Context, name string, value float64, attrs ...attribute.KeyValue) error {
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	histogram, err := helperFloat64Histogram(meter, name, "")
+	if err != nil {
+		return err
+	}
+
+	histogram.Record(ctx, value, metric.WithAttributes(withDebugRequestAttribute(ctx, attrs)...))
+
+	return nil
+}
+
+// RecordOnce reports a single value as the named gauge of the meter in the context, for short jobs that emit a final
+// measurement before exiting. The value is observed by an immediate collection of the global meter provider, after
+// which the callback is unregistered so later collections no longer report it
+func RecordOnce(ctx context.Context, name string, value float64, attrs ...attribute.KeyValue) error {
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	gauge, err := meter.Float64ObservableGauge(name)
+	if err != nil {
+		return err
+	}
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		observer.ObserveFloat64(gauge, value, metric.WithAttributes(attrs...))
+		return nil
+	}, gauge)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(CollectMetrics(ctx), registration.Unregister())
+}
+
+// RegisterAtomicGauge registers an observable gauge that reports the current value of the atomic on every
+// collection. The callback is owned by the meter provider and stops when the provider shuts down
+func RegisterAtomicGauge(ctx context.Context, name string, val *atomic.Int64, opts ...metric.Int64ObservableGaugeOption) error {
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return registerAtomicGauge(meter, name, val, opts...)
+}
+
+// registerAtomicGauge registers an observable gauge reading the atomic with the meter
+func registerAtomicGauge(meter metric.Meter, name string, val *atomic.Int64, opts ...metric.Int64ObservableGaugeOption) error {
+	callback := metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+		observer.Observe(val.Load())
+		return nil
+	})
+
+	_, err := meter.Int64ObservableGauge(name, append(opts, callback)...)
+
+	return err
+}
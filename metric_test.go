@@ -0,0 +1,183 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestContextInstruments(t *testing.T) {
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer provider.Shutdown(ctx)
+
+	ctx = AddMeterContext(ctx, provider.Meter("test"))
+
+	counter, err := Int64Counter(ctx, "requests")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+
+	counter.Add(ctx, 2)
+
+	histogram, err := Float64Histogram(ctx, "latency")
+	if err != nil {
+		t.Fatalf("Float64Histogram() error = %v", err)
+	}
+
+	histogram.Record(ctx, 1.5)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	sum, ok := metrics["requests"].(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 2 {
+		t.Errorf("requests = %+v, want a single data point of 2", metrics["requests"])
+	}
+
+	hist, ok := metrics["latency"].(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Sum != 1.5 {
+		t.Errorf("latency = %+v, want a single data point summing to 1.5", metrics["latency"])
+	}
+}
+
+func TestContextInstrumentsMissingMeter(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{
+			name: "Int64Counter",
+			fn: func() error {
+				_, err := Int64Counter(ctx, "requests")
+				return err
+			},
+		},
+		{
+			name: "Float64Histogram",
+			fn: func() error {
+				_, err := Float64Histogram(ctx, "latency")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn()
+
+			var meterErr MeterError
+			if !errors.As(err, &meterErr) {
+				t.Errorf("error = %v, want MeterError", err)
+			}
+
+			if !errors.Is(err, ErrMeterMissing) {
+				t.Errorf("error = %v, want ErrMeterMissing", err)
+			}
+		})
+	}
+}
+
+func TestIncrAndRecord(t *testing.T) {
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer provider.Shutdown(ctx)
+
+	meter := newRegistryMeter(provider.Meter("test"), nil)
+	ctx = AddMeterContext(ctx, meter)
+
+	for range 3 {
+		if err := Incr(ctx, "requests"); err != nil {
+			t.Fatalf("Incr() error = %v", err)
+		}
+
+		if err := Record(ctx, "latency", 0.5); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	counter, _ := helperInt64Counter(meter, "requests")
+	if cached, _ := helperInt64Counter(meter, "requests"); cached != counter {
+		t.Error("helperInt64Counter() created a new counter for a cached name")
+	}
+
+	if got := len(meter.registered()); got != 2 {
+		t.Errorf("registered %d instruments, want 2", got)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	if sum, ok := metrics["requests"].(metricdata.Sum[int64]); !ok || sum.DataPoints[0].Value != 3 {
+		t.Errorf("requests = %+v, want 3", metrics["requests"])
+	}
+
+	if hist, ok := metrics["latency"].(metricdata.Histogram[float64]); !ok || hist.DataPoints[0].Count != 3 {
+		t.Errorf("latency = %+v, want 3 measurements", metrics["latency"])
+	}
+}
+
+func TestIncrMissingMeter(t *testing.T) {
+	if err := Incr(context.Background(), "requests"); !errors.Is(err, ErrMeterMissing) {
+		t.Errorf("Incr() error = %v, want ErrMeterMissing", err)
+	}
+}
+
+func BenchmarkIncr(b *testing.B) {
+	ctx := context.Background()
+
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+
+	ctx = AddMeterContext(ctx, newRegistryMeter(provider.Meter("bench"), nil))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		Incr(ctx, "requests")
+	}
+}
+
+func BenchmarkRecord(b *testing.B) {
+	ctx := context.Background()
+
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+
+	ctx = AddMeterContext(ctx, newRegistryMeter(provider.Meter("bench"), nil))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		Record(ctx, "latency", 0.5)
+	}
+}
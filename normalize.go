@@ -0,0 +1,164 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// KeyConventionSnakeCase rewrites attribute keys such as httpStatus or http.status to http_status
+const KeyConventionSnakeCase = "snake_case"
+
+// keyNormalizer returns the function rewriting attribute keys to the convention. An empty convention returns nil
+func keyNormalizer(convention string) (func(string) string, error) {
+	switch convention {
+	case "":
+		return nil, nil
+	case KeyConventionSnakeCase:
+		return toSnakeCase, nil
+	default:
+		return nil, KeyConventionError{convention}
+	}
+}
+
+// toSnakeCase converts camelCase, dot.notation and kebab-case keys to snake_case
+func toSnakeCase(key string) string {
+	runes := []rune(key)
+
+	var sb strings.Builder
+	sb.Grow(len(key) + 4)
+
+	var last rune
+	for i, r := range runes {
+		switch {
+		case r == '.' || r == '-' || r == ' ':
+			r = '_'
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteRune('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		if r == '_' && last == '_' {
+			continue
+		}
+
+		sb.WriteRune(r)
+		last = r
+	}
+
+	return sb.String()
+}
+
+// normalizeAttributes returns a copy of the attributes with normalized keys
+func normalizeAttributes(attrs []attribute.KeyValue, normalize func(string) string) []attribute.KeyValue {
+	normalized := make([]attribute.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		normalized[i] = attribute.KeyValue{Key: attribute.Key(normalize(string(attr.Key))), Value: attr.Value}
+	}
+
+	return normalized
+}
+
+// normalizeSet returns a set with normalized keys
+func normalizeSet(set attribute.Set, normalize func(string) string) attribute.Set {
+	return attribute.NewSet(normalizeAttributes(set.ToSlice(), normalize)...)
+}
+
+// normalizedSpan overrides the attributes and events of a span with normalized keys
+type normalizedSpan struct {
+	sdktrace.ReadOnlySpan
+
+	attrs  []attribute.KeyValue
+	events []sdktrace.Event
+}
+
+func (s normalizedSpan) Attributes() []attribute.KeyValue { return s.attrs }
+
+func (s normalizedSpan) Events() []sdktrace.Event { return s.events }
+
+// normalizeSpanExporter rewrites span and event attribute keys before export
+type normalizeSpanExporter struct {
+	sdktrace.SpanExporter
+	normalize func(string) string
+}
+
+func (e normalizeSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	normalized := make([]sdktrace.ReadOnlySpan, len(spans))
+	for i, span := range spans {
+		events := slices.Clone(span.Events())
+		for j := range events {
+			events[j].Attributes = normalizeAttributes(events[j].Attributes, e.normalize)
+		}
+
+		normalized[i] = normalizedSpan{span, normalizeAttributes(span.Attributes(), e.normalize), events}
+	}
+
+	return e.SpanExporter.ExportSpans(ctx, normalized)
+}
+
+// normalizeMetricExporter rewrites data point attribute keys before export
+type normalizeMetricExporter struct {
+	sdkmetric.Exporter
+	normalize func(string) string
+}
+
+func (e normalizeMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	for i := range rm.ScopeMetrics {
+		for j := range rm.ScopeMetrics[i].Metrics {
+			normalizeAggregation(rm.ScopeMetrics[i].Metrics[j].Data, e.normalize)
+		}
+	}
+
+	return e.Exporter.Export(ctx, rm)
+}
+
+// normalizeAggregation rewrites the attribute keys of every data point in the aggregation
+func normalizeAggregation(data metricdata.Aggregation, normalize func(string) string) {
+	switch agg := data.(type) {
+	case metricdata.Gauge[int64]:
+		normalizeDataPoints(agg.DataPoints, normalize)
+	case metricdata.Gauge[float64]:
+		normalizeDataPoints(agg.DataPoints, normalize)
+	case metricdata.Sum[int64]:
+		normalizeDataPoints(agg.DataPoints, normalize)
+	case metricdata.Sum[float64]:
+		normalizeDataPoints(agg.DataPoints, normalize)
+	case metricdata.Histogram[int64]:
+		for i := range agg.DataPoints {
+			agg.DataPoints[i].Attributes = normalizeSet(agg.DataPoints[i].Attributes, normalize)
+		}
+	case metricdata.Histogram[float64]:
+		for i := range agg.DataPoints {
+			agg.DataPoints[i].Attributes = normalizeSet(agg.DataPoints[i].Attributes, normalize)
+		}
+	case metricdata.ExponentialHistogram[int64]:
+		for i := range agg.DataPoints {
+			agg.DataPoints[i].Attributes = normalizeSet(agg.DataPoints[i].Attributes, normalize)
+		}
+	case metricdata.ExponentialHistogram[float64]:
+		for i := range agg.DataPoints {
+			agg.DataPoints[i].Attributes = normalizeSet(agg.DataPoints[i].Attributes, normalize)
+		}
+	case metricdata.Summary:
+		for i := range agg.DataPoints {
+			agg.DataPoints[i].Attributes = normalizeSet(agg.DataPoints[i].Attributes, normalize)
+		}
+	}
+}
+
+func normalizeDataPoints[N int64 | float64](points []metricdata.DataPoint[N], normalize func(string) string) {
+	for i := range points {
+		points[i].Attributes = normalizeSet(points[i].Attributes, normalize)
+	}
+}
@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// observeHistogram returns the name.duration histogram of the meter. Histograms of the meter created by InitProviders
+// are cached with it, so they are released with the meter provider
+func observeHistogram(meter metric.Meter, name string) metric.Float64Histogram {
+	histogram, err := helperFloat64Histogram(meter, name+".duration", "s")
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return histogram
+}
+
+// Observe runs fn in a span with the tracer in the context and records its duration in seconds to the name.duration
+// histogram of the meter in the context, falling back to the global providers. An error returned by fn is recorded
+// on the span with the ErrorStatusMapper, classified as the error.type of the measurement and returned
+func Observe(ctx context.Context, name string, fn func(context.Context) error, opts ...trace.SpanStartOption) error {
+	tracer, err := TracerFromContext(ctx)
+	if err != nil {
+		tracer = globalTracer()
+	}
+
+	meter, err := MeterFromContext(ctx)
+	if err != nil {
+		meter = otel.Meter(instrumentationName)
+	}
+
+	histogram := observeHistogram(meter, name)
+
+	ctx, span := tracer.Start(ctx, name, opts...)
+	defer span.End()
+
+	start := time.Now()
+	err = fn(ctx)
+	elapsed := time.Since(start)
+
+	var attrs []attribute.KeyValue
+	if err != nil {
+		RecordError(span, err)
+
+		if typ := errorType(err); typ != "" {
+			attrs = append(attrs, semconv.ErrorTypeKey.String(typ))
+		}
+	}
+
+	if histogram != nil {
+		histogram.Record(ctx, elapsed.Seconds(), metric.WithAttributes(withDebugRequestAttribute(ctx, attrs)...))
+	}
+
+	return err
+}
@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestObserveCachesHistogram(t *testing.T) {
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer provider.Shutdown(ctx)
+
+	meter := newRegistryMeter(provider.Meter("test"), nil)
+	ctx = AddMeterContext(ctx, meter)
+
+	for range 3 {
+		if err := Observe(ctx, "job", func(context.Context) error { return nil }); err != nil {
+			t.Fatalf("Observe() error = %v", err)
+		}
+	}
+
+	if observeHistogram(meter, "job") != observeHistogram(meter, "job") {
+		t.Error("observeHistogram() created a new histogram for a cached meter and name")
+	}
+
+	if got := len(meter.registered()); got != 1 {
+		t.Errorf("registered %d instruments, want 1", got)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	hist, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Count != 3 {
+		t.Errorf("job.duration = %+v, want 3 measurements", rm.ScopeMetrics[0].Metrics[0].Data)
+	}
+}
+
+func BenchmarkObserve(b *testing.B) {
+	ctx := context.Background()
+
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	defer provider.Shutdown(ctx)
+
+	ctx = AddMeterContext(ctx, newRegistryMeter(provider.Meter("bench"), nil))
+	fn := func(context.Context) error { return nil }
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		Observe(ctx, "job", fn)
+	}
+}
@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"crypto/tls"
+	"maps"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// otlpEndpointEnv is the standard environment variable for the collector endpoint
+const otlpEndpointEnv = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+// Option configures a Config created by NewConfig
+type Option func(*Config)
+
+// NewConfig creates a config from the options. The endpoint defaults to OTEL_EXPORTER_OTLP_ENDPOINT, verified against
+// the system roots when it is https, and a service name is required. A Config struct literal is still accepted by
+// InitProviders
+func NewConfig(opts ...Option) (*Config, error) {
+	cfg := &Config{}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.OtelEndpoint == "" {
+		var secure bool
+		cfg.OtelEndpoint, secure = envEndpoint(cfg.Protocol, otlpEndpointEnv)
+
+		if secure && cfg.TlsConfig == nil && !cfg.Insecure {
+			cfg.TlsConfig = &tls.Config{}
+		}
+	}
+
+	if cfg.ServiceName == "" {
+		return nil, ServiceNameError{}
+	}
+
+	if cfg.MetricInterval < 0 {
+		return nil, MetricIntervalError{cfg.MetricInterval}
+	}
+
+	return cfg, nil
+}
+
+// WithServiceName sets the service.name resource attribute
+func WithServiceName(name string) Option {
+	return func(cfg *Config) {
+		cfg.ServiceName = name
+	}
+}
+
+// WithResourceAttributes adds attributes such as deployment.environment or ownership labels to the resource
+func WithResourceAttributes(attrs ...attribute.KeyValue) Option {
+	return func(cfg *Config) {
+		cfg.ResourceAttributes = append(cfg.ResourceAttributes, attrs...)
+	}
+}
+
+// WithEndpoint sets the collector endpoint as host:port
+func WithEndpoint(endpoint string) Option {
+	return func(cfg *Config) {
+		cfg.OtelEndpoint = endpoint
+	}
+}
+
+// WithHeaders adds headers sent with every export to the collector, e.g. an API key
+func WithHeaders(headers map[string]string) Option {
+	return func(cfg *Config) {
+		if cfg.Headers == nil {
+			cfg.Headers = make(map[string]string, len(headers))
+		}
+
+		maps.Copy(cfg.Headers, headers)
+	}
+}
+
+// WithProtocol sets the OTLP transport, ProtocolGRPC or ProtocolHTTP
+func WithProtocol(protocol string) Option {
+	return func(cfg *Config) {
+		cfg.Protocol = protocol
+	}
+}
+
+// WithTLS sets the TLS config of the collector connection
+func WithTLS(tlsConfig *tls.Config) Option {
+	return func(cfg *Config) {
+		cfg.TlsConfig = tlsConfig
+	}
+}
+
+// WithInsecure connects to the collector over plaintext, for local development
+func WithInsecure() Option {
+	return func(cfg *Config) {
+		cfg.Insecure = true
+	}
+}
+
+// WithLambda enables the AWS Lambda resource detector
+func WithLambda(lambda bool) Option {
+	return func(cfg *Config) {
+		cfg.Lambda = lambda
+	}
+}
+
+// WithTraceSampler sets the sampler deciding which spans are sampled
+func WithTraceSampler(sampler sdktrace.Sampler) Option {
+	return func(cfg *Config) {
+		cfg.Sampler = sampler
+	}
+}
+
+// WithSampleRatio samples the fraction of new traces with a parent based sampler
+func WithSampleRatio(ratio float64) Option {
+	return func(cfg *Config) {
+		cfg.SampleRatio = ratio
+	}
+}
+
+// WithMetricInterval sets the interval between metric exports
+func WithMetricInterval(interval time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.MetricInterval = interval
+	}
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestResourceAttributesOnSpans(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "")
+
+	optionConfig, err := NewConfig(WithServiceName("checkout"), WithResourceAttributes(attribute.String("team", "payments")))
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  *Config
+	}{
+		{name: "WithResourceAttributes", cfg: optionConfig},
+		{name: "Config.ResourceAttributes", cfg: &Config{ServiceName: "checkout", ResourceAttributes: []attribute.KeyValue{attribute.String("team", "payments")}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			res, err := setupResource(ctx, tt.cfg)
+			if err != nil {
+				t.Fatalf("setupResource() error = %v", err)
+			}
+
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithResource(res), sdktrace.WithSyncer(exporter))
+			defer provider.Shutdown(ctx)
+
+			_, span := provider.Tracer("test").Start(ctx, "span")
+			span.End()
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("exported %d spans, want 1", len(spans))
+			}
+
+			attrs := spans[0].Resource.Set()
+
+			if got, _ := attrs.Value("team"); got.AsString() != "payments" {
+				t.Errorf("team = %q, want %q", got.AsString(), "payments")
+			}
+
+			if got, _ := attrs.Value("service.name"); got.AsString() != "checkout" {
+				t.Errorf("service.name = %q, want %q", got.AsString(), "checkout")
+			}
+		})
+	}
+}
+
+func TestNewConfigEndpointFromEnv(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          string
+		opts         []Option
+		wantEndpoint string
+		wantTLS      bool
+	}{
+		{name: "https", env: "https://collector:4317", wantEndpoint: "collector:4317", wantTLS: true},
+		{name: "http", env: "http://collector:4317", wantEndpoint: "collector:4317"},
+		{name: "host", env: "collector:4317", wantEndpoint: "collector:4317"},
+		{name: "https over http protocol", env: "https://collector:4318", opts: []Option{WithProtocol(ProtocolHTTP)}, wantEndpoint: "https://collector:4318", wantTLS: true},
+		{name: "https with insecure", env: "https://collector:4317", opts: []Option{WithInsecure()}, wantEndpoint: "collector:4317"},
+		{name: "explicit endpoint", env: "https://collector:4317", opts: []Option{WithEndpoint("local:4317")}, wantEndpoint: "local:4317"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(otlpEndpointEnv, tt.env)
+
+			cfg, err := NewConfig(append(tt.opts, WithServiceName("checkout"))...)
+			if err != nil {
+				t.Fatalf("NewConfig() error = %v", err)
+			}
+
+			if cfg.OtelEndpoint != tt.wantEndpoint {
+				t.Errorf("OtelEndpoint = %q, want %q", cfg.OtelEndpoint, tt.wantEndpoint)
+			}
+
+			if gotTLS := cfg.TlsConfig != nil; gotTLS != tt.wantTLS {
+				t.Errorf("TlsConfig set = %v, want %v", gotTLS, tt.wantTLS)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const panicFlushTimeout = 5 * time.Second
+
+// RecoverAndLog reports a panic as a fatal OTLP log record, using the logger provider in the context, and as an
+// exception event on the active span. Both pipelines are flushed before the panic is re-raised so the crash reaches
+// the backend. It must be deferred directly, e.g. defer telemetry.RecoverAndLog(ctx)
+func RecoverAndLog(ctx context.Context) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	message := fmt.Sprint(recovered)
+	panicType := fmt.Sprintf("%T", recovered)
+	stack := string(debug.Stack())
+
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), panicFlushTimeout)
+	defer cancel()
+
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.AddEvent(semconv.ExceptionEventName, trace.WithAttributes(
+			semconv.ExceptionType(panicType),
+			semconv.ExceptionMessage(message),
+			semconv.ExceptionStacktrace(stack),
+			semconv.ExceptionEscaped(true),
+		))
+		span.SetStatus(codes.Error, message)
+		span.End()
+	}
+
+	if logProvider, err := LogProviderFromContext(ctx); err == nil {
+		var record log.Record
+		record.SetTimestamp(time.Now())
+		record.SetSeverity(log.SeverityFatal)
+		record.SetSeverityText("PANIC")
+		record.SetBody(log.StringValue(message))
+		record.AddAttributes(
+			log.String(string(semconv.ExceptionTypeKey), panicType),
+			log.String(string(semconv.ExceptionStacktraceKey), stack),
+		)
+
+		logProvider.Logger(instrumentationName).Emit(ctx, record)
+		logProvider.ForceFlush(flushCtx)
+	}
+
+	if traceProvider, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); ok {
+		traceProvider.ForceFlush(flushCtx)
+	}
+
+	panic(recovered)
+}
@@ -0,0 +1,185 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// preInit holds the provider registered by CapturePreInitSpans until InitProviders takes over
+var preInit atomic.Pointer[preInitProvider]
+
+// CapturePreInitSpans registers a global tracer provider that keeps up to size spans started before InitProviders,
+// e.g. by libraries during package initialization. Without it those spans are started on the no-op global provider
+// and lost. Call it first thing in main.
+//
+// Captured spans are sampled and exported through the configured collectors once InitProviders runs, with the
+// resource of the config. Spans started afterwards, including by tracers obtained before init, use the configured
+// provider. A warning is logged for the first span started before init to surface ordering bugs
+func CapturePreInitSpans(size int) {
+	buffer := &preInitProcessor{size: size}
+
+	provider := &preInitProvider{
+		capture: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(buffer)),
+		buffer:  buffer,
+	}
+
+	preInit.Store(provider)
+	otel.SetTracerProvider(provider)
+}
+
+// preInitProvider records spans on a capture provider until a configured provider is set, then delegates to it
+type preInitProvider struct {
+	embedded.TracerProvider
+
+	capture    *sdktrace.TracerProvider
+	buffer     *preInitProcessor
+	configured atomic.Pointer[trace.TracerProvider]
+	warn       sync.Once
+}
+
+func (p *preInitProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &preInitTracer{
+		provider: p,
+		name:     name,
+		opts:     opts,
+	}
+}
+
+// attach delegates new spans to the configured provider and exports captured spans with the resource through the
+// exporters. The returned function flushes spans that were started before init but end later, and must run before
+// the exporters shut down
+func (p *preInitProvider) attach(provider trace.TracerProvider, exporters []sdktrace.SpanExporter, res *resource.Resource) func(context.Context) error {
+	processor := sdktrace.NewBatchSpanProcessor(fanoutSpanExporter(exporters))
+
+	p.configured.Store(&provider)
+	p.buffer.attach(resourceProcessor{processor, res})
+
+	return func(ctx context.Context) error {
+		return errors.Join(processor.Shutdown(ctx), p.capture.Shutdown(ctx))
+	}
+}
+
+// discard delegates new spans to the provider and drops captured spans
+func (p *preInitProvider) discard(provider trace.TracerProvider) {
+	p.configured.Store(&provider)
+	p.buffer.attach(nil)
+}
+
+// preInitTracer starts spans on the configured provider once it is set and on the capture provider before
+type preInitTracer struct {
+	embedded.Tracer
+
+	provider *preInitProvider
+	name     string
+	opts     []trace.TracerOption
+}
+
+func (t *preInitTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if configured := t.provider.configured.Load(); configured != nil {
+		return (*configured).Tracer(t.name, t.opts...).Start(ctx, name, opts...)
+	}
+
+	t.provider.warn.Do(func() {
+		slog.Warn("span started before telemetry init", "span", name)
+	})
+
+	return t.provider.capture.Tracer(t.name, t.opts...).Start(ctx, name, opts...)
+}
+
+// preInitProcessor keeps up to size ended spans until attached, then passes every span to the attached processor
+type preInitProcessor struct {
+	mu       sync.Mutex
+	size     int
+	spans    []sdktrace.ReadOnlySpan
+	attached bool
+	next     sdktrace.SpanProcessor
+}
+
+func (p *preInitProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p *preInitProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.mu.Lock()
+	if !p.attached {
+		if len(p.spans) < p.size {
+			p.spans = append(p.spans, s)
+		}
+		p.mu.Unlock()
+		return
+	}
+
+	next := p.next
+	p.mu.Unlock()
+
+	if next != nil {
+		next.OnEnd(s)
+	}
+}
+
+// attach replays the captured spans to next, which receives every later span. A nil next drops them
+func (p *preInitProcessor) attach(next sdktrace.SpanProcessor) {
+	p.mu.Lock()
+	spans := p.spans
+	p.spans = nil
+	p.next = next
+	p.attached = true
+	p.mu.Unlock()
+
+	if next == nil {
+		return
+	}
+
+	for _, s := range spans {
+		next.OnEnd(s)
+	}
+}
+
+func (p *preInitProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *preInitProcessor) ForceFlush(context.Context) error { return nil }
+
+// resourceProcessor replaces the resource of ended spans
+type resourceProcessor struct {
+	sdktrace.SpanProcessor
+
+	resource *resource.Resource
+}
+
+func (p resourceProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.SpanProcessor.OnEnd(resourceSpan{s, p.resource})
+}
+
+// resourceSpan reports a different resource than the provider that recorded it
+type resourceSpan struct {
+	sdktrace.ReadOnlySpan
+
+	resource *resource.Resource
+}
+
+func (s resourceSpan) Resource() *resource.Resource {
+	return s.resource
+}
+
+// fanoutSpanExporter exports spans to every exporter. The exporters are owned by another provider and are not shut
+// down
+type fanoutSpanExporter []sdktrace.SpanExporter
+
+func (e fanoutSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var err error
+	for _, exporter := range e {
+		err = errors.Join(err, exporter.ExportSpans(ctx, spans))
+	}
+
+	return err
+}
+
+func (e fanoutSpanExporter) Shutdown(context.Context) error { return nil }
@@ -0,0 +1,143 @@
+package telemetry
+
+import (
+	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// ProfileLowLatency exports small batches quickly and samples every trace
+	ProfileLowLatency = "low_latency"
+
+	// ProfileHighThroughput exports large compressed batches and samples a quarter of traces
+	ProfileHighThroughput = "high_throughput"
+
+	// ProfileLowCost minimizes egress with large infrequent compressed batches and samples 5% of traces
+	ProfileLowCost = "low_cost"
+)
+
+// profile is a named preset of export tuning. Zero values keep the SDK defaults
+type profile struct {
+	batchTimeout       time.Duration
+	maxExportBatchSize int
+	maxQueueSize       int
+	metricInterval     time.Duration
+	compression        string
+	sampleRatio        float64
+}
+
+var profiles = map[string]profile{
+	ProfileLowLatency: {
+		batchTimeout:       500 * time.Millisecond,
+		maxExportBatchSize: 128,
+		maxQueueSize:       2048,
+		metricInterval:     5 * time.Second,
+	},
+	ProfileHighThroughput: {
+		batchTimeout:       5 * time.Second,
+		maxExportBatchSize: 2048,
+		maxQueueSize:       16384,
+		metricInterval:     30 * time.Second,
+		compression:        "gzip",
+		sampleRatio:        0.25,
+	},
+	ProfileLowCost: {
+		batchTimeout:       10 * time.Second,
+		maxExportBatchSize: 4096,
+		maxQueueSize:       8192,
+		metricInterval:     60 * time.Second,
+		compression:        "gzip",
+		sampleRatio:        0.05,
+	},
+}
+
+// lookupProfile returns the named profile. An empty name returns the zero profile
+func lookupProfile(name string) (profile, error) {
+	if name == "" {
+		return profile{}, nil
+	}
+
+	p, ok := profiles[name]
+	if !ok {
+		return profile{}, ProfileError{name}
+	}
+
+	return p, nil
+}
+
+// sampler returns a parent based ratio sampler when the profile samples a fraction of traces
+func (p profile) sampler() sdktrace.Sampler {
+	if p.sampleRatio <= 0 || p.sampleRatio >= 1 {
+		return sdktrace.AlwaysSample()
+	}
+
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(p.sampleRatio))
+}
+
+// batchSpanOptions returns the batch span processor options of the profile
+func (p profile) batchSpanOptions() []sdktrace.BatchSpanProcessorOption {
+	var opts []sdktrace.BatchSpanProcessorOption
+	if p.batchTimeout > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(p.batchTimeout))
+	}
+	if p.maxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(p.maxExportBatchSize))
+	}
+	if p.maxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(p.maxQueueSize))
+	}
+
+	return opts
+}
+
+// BatchSpanConfig tunes the batch span processor of every collector. Zero values keep the Profile or SDK defaults
+type BatchSpanConfig struct {
+	// MaxQueueSize is the number of ended spans buffered for export. Spans ended while the queue is full are dropped
+	MaxQueueSize int
+
+	// MaxExportBatchSize is the maximum number of spans per export
+	MaxExportBatchSize int
+
+	// BatchTimeout is the maximum delay before queued spans are exported
+	BatchTimeout time.Duration
+
+	// ExportTimeout bounds a single export of the processor, including exporter retries
+	ExportTimeout time.Duration
+}
+
+// options returns the batch span processor options of the fields that are set
+func (c BatchSpanConfig) options() []sdktrace.BatchSpanProcessorOption {
+	var opts []sdktrace.BatchSpanProcessorOption
+	if c.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(c.MaxQueueSize))
+	}
+	if c.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(c.MaxExportBatchSize))
+	}
+	if c.BatchTimeout > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(c.BatchTimeout))
+	}
+	if c.ExportTimeout > 0 {
+		opts = append(opts, sdktrace.WithExportTimeout(c.ExportTimeout))
+	}
+
+	return opts
+}
+
+// batchLogOptions returns the batch log processor options of the profile
+func (p profile) batchLogOptions() []sdklog.BatchProcessorOption {
+	var opts []sdklog.BatchProcessorOption
+	if p.batchTimeout > 0 {
+		opts = append(opts, sdklog.WithExportInterval(p.batchTimeout))
+	}
+	if p.maxExportBatchSize > 0 {
+		opts = append(opts, sdklog.WithExportMaxBatchSize(p.maxExportBatchSize))
+	}
+	if p.maxQueueSize > 0 {
+		opts = append(opts, sdklog.WithMaxQueueSize(p.maxQueueSize))
+	}
+
+	return opts
+}
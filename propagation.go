@@ -0,0 +1,195 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHeaders maps the nonstandard headers of legacy upstreams to trace context. Extraction only, trace context is
+// never injected into these headers
+type TraceHeaders struct {
+	// TraceID is the header carrying the hex encoded 64 or 128 bit trace id
+	TraceID string
+
+	// SpanID is the header carrying the hex encoded parent span id
+	SpanID string
+
+	// Sampled is an optional header carrying the sampling decision as "1" or "true"
+	Sampled string
+}
+
+const (
+	// PropagatorTraceContext is W3C trace context
+	PropagatorTraceContext = "tracecontext"
+
+	// PropagatorBaggage is W3C baggage
+	PropagatorBaggage = "baggage"
+
+	// PropagatorB3 is the single b3 header of Zipkin
+	PropagatorB3 = "b3"
+
+	// PropagatorB3Multi is the multiple X-B3-* headers of Zipkin
+	PropagatorB3Multi = "b3multi"
+
+	// PropagatorXRay is the X-Amzn-Trace-Id header of AWS X-Ray
+	PropagatorXRay = "xray"
+)
+
+// InjectHTTP injects the trace context of the context into the headers with the global propagator
+func InjectHTTP(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHTTP returns a context carrying the trace context extracted from the headers with the global propagator
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// InjectMap injects the trace context of the context into the map with the global propagator, e.g. for message
+// attributes
+func InjectMap(ctx context.Context, carrier map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// ExtractMap returns a context carrying the trace context extracted from the map with the global propagator
+func ExtractMap(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// newPropagator creates the propagator registered globally by InitProviders. Config.Propagators, by default W3C trace
+// context and X-Ray, are used in both directions unless Config.InjectPropagators or Config.ExtractPropagators replace
+// them
+func newPropagator(cfg *Config) (propagation.TextMapPropagator, error) {
+	defaults := []propagation.TextMapPropagator{
+		propagation.TraceContext{},
+		xray.Propagator{},
+	}
+
+	if len(cfg.Propagators) > 0 {
+		defaults = make([]propagation.TextMapPropagator, 0, len(cfg.Propagators))
+		for _, name := range cfg.Propagators {
+			propagator, err := propagatorByName(name)
+			if err != nil {
+				return nil, err
+			}
+
+			defaults = append(defaults, propagator)
+		}
+	}
+
+	extract := defaults
+	if len(cfg.ExtractPropagators) > 0 {
+		extract = cfg.ExtractPropagators
+	}
+
+	// custom headers are extracted first so standard formats win when both are present
+	if cfg.TraceHeaders != nil {
+		extract = append([]propagation.TextMapPropagator{headerPropagator{*cfg.TraceHeaders}}, extract...)
+	}
+
+	if len(cfg.InjectPropagators) == 0 && len(cfg.ExtractPropagators) == 0 {
+		return propagation.NewCompositeTextMapPropagator(extract...), nil
+	}
+
+	inject := defaults
+	if len(cfg.InjectPropagators) > 0 {
+		inject = cfg.InjectPropagators
+	}
+
+	return splitPropagator{
+		inject:  propagation.NewCompositeTextMapPropagator(inject...),
+		extract: propagation.NewCompositeTextMapPropagator(extract...),
+	}, nil
+}
+
+// propagatorByName returns the propagator of a name accepted by Config.Propagators
+func propagatorByName(name string) (propagation.TextMapPropagator, error) {
+	switch name {
+	case PropagatorTraceContext:
+		return propagation.TraceContext{}, nil
+	case PropagatorBaggage:
+		return propagation.Baggage{}, nil
+	case PropagatorB3:
+		return b3.New(), nil
+	case PropagatorB3Multi:
+		return b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)), nil
+	case PropagatorXRay:
+		return xray.Propagator{}, nil
+	default:
+		return nil, PropagatorError{name}
+	}
+}
+
+// splitPropagator injects and extracts with different propagators, e.g. to accept legacy formats while only
+// injecting W3C trace context
+type splitPropagator struct {
+	inject  propagation.TextMapPropagator
+	extract propagation.TextMapPropagator
+}
+
+func (p splitPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	p.inject.Inject(ctx, carrier)
+}
+
+func (p splitPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return p.extract.Extract(ctx, carrier)
+}
+
+func (p splitPropagator) Fields() []string {
+	return p.inject.Fields()
+}
+
+// headerPropagator extracts trace context from configured custom headers
+type headerPropagator struct {
+	headers TraceHeaders
+}
+
+func (p headerPropagator) Inject(context.Context, propagation.TextMapCarrier) {}
+
+func (p headerPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	traceHex := carrier.Get(p.headers.TraceID)
+	if len(traceHex) == 16 {
+		traceHex = strings.Repeat("0", 16) + traceHex
+	}
+
+	traceID, err := trace.TraceIDFromHex(traceHex)
+	if err != nil {
+		return ctx
+	}
+
+	spanID, err := trace.SpanIDFromHex(carrier.Get(p.headers.SpanID))
+	if err != nil {
+		return ctx
+	}
+
+	var flags trace.TraceFlags
+	if p.headers.Sampled != "" {
+		switch strings.ToLower(carrier.Get(p.headers.Sampled)) {
+		case "1", "true":
+			flags = trace.FlagsSampled
+		}
+	}
+
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: flags,
+		Remote:     true,
+	}))
+}
+
+func (p headerPropagator) Fields() []string {
+	fields := []string{p.headers.TraceID, p.headers.SpanID}
+	if p.headers.Sampled != "" {
+		fields = append(fields, p.headers.Sampled)
+	}
+
+	return fields
+}
@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPropagationRoundTrip(t *testing.T) {
+	prop, err := newPropagator(&Config{})
+	if err != nil {
+		t.Fatalf("newPropagator() error = %v", err)
+	}
+
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(prop)
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x0a, 0x0b, 0x0c, 0x0d, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	tests := []struct {
+		name      string
+		roundTrip func(ctx context.Context) context.Context
+	}{
+		{
+			name: "http header",
+			roundTrip: func(ctx context.Context) context.Context {
+				header := http.Header{}
+				InjectHTTP(ctx, header)
+
+				return ExtractHTTP(context.Background(), header)
+			},
+		},
+		{
+			name: "map",
+			roundTrip: func(ctx context.Context) context.Context {
+				carrier := map[string]string{}
+				InjectMap(ctx, carrier)
+
+				return ExtractMap(context.Background(), carrier)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := trace.SpanContextFromContext(tt.roundTrip(ctx))
+
+			if !got.Equal(sc.WithRemote(true)) {
+				t.Errorf("extracted span context = %+v, want %+v", got, sc)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// activeProviders holds the providers of the last NewProviders call until they shut down
+var activeProviders atomic.Pointer[Providers]
+
+// Flush exports the telemetry buffered by the providers created by InitProviders without shutting them down, e.g.
+// before a Lambda handler returns and the execution environment freezes. A ProvidersError is returned when no
+// providers are initialized
+func Flush(ctx context.Context) error {
+	providers := activeProviders.Load()
+	if providers == nil {
+		return ProvidersError{}
+	}
+
+	return providers.ForceFlush(ctx)
+}
+
+// Providers are the SDK providers created by NewProviders. A provider is nil when its signal is disabled, or with
+// Config.Disabled
+type Providers struct {
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *sdkmetric.MeterProvider
+	LoggerProvider *sdklog.LoggerProvider
+
+	flush    ShutdownFuncs
+	shutdown CleanupFunc
+	timeout  time.Duration
+}
+
+// ForceFlush exports the telemetry buffered by every provider, e.g. at a checkpoint of a batch job. Each provider is
+// bounded by Config.ShutdownTimeout
+func (p *Providers) ForceFlush(ctx context.Context) error {
+	var err error
+	for _, fn := range p.flush {
+		err = errors.Join(err, callWithTimeout(ctx, p.timeout, fn))
+	}
+
+	return err
+}
+
+// Shutdown flushes and shuts down the providers and closes the collector connections, like the cleanup function of
+// InitProviders
+func (p *Providers) Shutdown(ctx context.Context) error {
+	activeProviders.CompareAndSwap(p, nil)
+
+	return p.shutdown(ctx)
+}
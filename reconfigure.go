@@ -0,0 +1,317 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// activePipeline holds the exporters of the providers created by InitProviders
+var activePipeline atomic.Pointer[pipeline]
+
+// Reconfigure repoints the exporters of the providers created by InitProviders to the collectors of the config
+// without restarting the application. New connections and exporters are created first, then swapped in once
+// in-flight exports finish, and the old exporters and connections are shut down. The tracer and meter in existing
+// contexts keep working.
+//
+// Only connection settings such as endpoints, TLS, headers and timeouts take effect. The config must describe the same
+// number of backends and metric routes. Every other setting, such as the protocol, self-metrics, attribute key
+// normalization, circuit breaker and metric temporality, keeps its initial value. The collector health metric reports
+// the new connections
+func Reconfigure(ctx context.Context, cfg *Config) error {
+	pipe := activePipeline.Load()
+	if pipe == nil {
+		return ReconfigureError{}
+	}
+
+	return pipe.reconfigure(ctx, cfg)
+}
+
+// pipeline tracks the swappable exporters of every target so they can be repointed. A nil pipeline leaves exporters
+// unwrapped
+type pipeline struct {
+	mu      sync.Mutex
+	initial Config
+	targets []target
+	spans   []*swapSpanExporter
+	metrics []*swapMetricExporter
+	logs    []*swapLogExporter
+
+	// watcher reports the health of the current targets when Config.CollectorHealthMetric is set
+	watcher *collectorWatcher
+}
+
+func newPipeline(cfg *Config, targets []target) *pipeline {
+	return &pipeline{
+		initial: *cfg,
+		targets: targets,
+	}
+}
+
+// spanExporter wraps the exporter of the target at index so it can be swapped
+func (p *pipeline) spanExporter(index int, exporter sdktrace.SpanExporter) sdktrace.SpanExporter {
+	if p == nil {
+		return exporter
+	}
+
+	swap := &swapSpanExporter{index: index, exporter: exporter}
+	p.spans = append(p.spans, swap)
+
+	return swap
+}
+
+// metricExporter wraps the exporter of the target at index so it can be swapped
+func (p *pipeline) metricExporter(index int, exporter sdkmetric.Exporter) sdkmetric.Exporter {
+	if p == nil {
+		return exporter
+	}
+
+	swap := &swapMetricExporter{index: index, exporter: exporter}
+	p.metrics = append(p.metrics, swap)
+
+	return swap
+}
+
+// logExporter wraps the exporter of the target at index so it can be swapped
+func (p *pipeline) logExporter(index int, exporter sdklog.Exporter) sdklog.Exporter {
+	if p == nil {
+		return exporter
+	}
+
+	swap := &swapLogExporter{index: index, exporter: exporter}
+	p.logs = append(p.logs, swap)
+
+	return swap
+}
+
+// spanExporters returns the span exporters of every target
+func (p *pipeline) spanExporters() []sdktrace.SpanExporter {
+	exporters := make([]sdktrace.SpanExporter, 0, len(p.spans))
+	for _, exporter := range p.spans {
+		exporters = append(exporters, exporter)
+	}
+
+	return exporters
+}
+
+// close closes the connections of the current targets
+func (p *pipeline) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return closeTargets(p.targets)
+}
+
+func (p *pipeline) reconfigure(ctx context.Context, cfg *Config) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	next := withConnectionSettings(p.initial, cfg)
+
+	targets, err := newTargets(&next)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) != len(p.targets) {
+		return errors.Join(TargetLayoutError{}, closeTargets(targets))
+	}
+	for i := range targets {
+		if targets[i].metricsOnly() != p.targets[i].metricsOnly() || targets[i].signals != p.targets[i].signals {
+			return errors.Join(TargetLayoutError{}, closeTargets(targets))
+		}
+	}
+
+	spans := make([]sdktrace.SpanExporter, len(p.spans))
+	metrics := make([]sdkmetric.Exporter, len(p.metrics))
+	logs := make([]sdklog.Exporter, len(p.logs))
+
+	// every exporter is created before swapping so a failure leaves the current exporters in place
+	for i, swap := range p.spans {
+		if spans[i], err = newSpanExporter(ctx, &next, targets[swap.index]); err != nil {
+			return errors.Join(err, closeTargets(targets))
+		}
+	}
+	for i, swap := range p.metrics {
+		if metrics[i], err = newMetricExporter(ctx, &next, targets[swap.index]); err != nil {
+			return errors.Join(err, closeTargets(targets))
+		}
+	}
+	for i, swap := range p.logs {
+		if logs[i], err = newLogExporter(ctx, &next, targets[swap.index]); err != nil {
+			return errors.Join(err, closeTargets(targets))
+		}
+	}
+
+	for i, swap := range p.spans {
+		err = errors.Join(err, swap.swap(spans[i]).Shutdown(ctx))
+	}
+	for i, swap := range p.metrics {
+		err = errors.Join(err, swap.swap(metrics[i]).Shutdown(ctx))
+	}
+	for i, swap := range p.logs {
+		err = errors.Join(err, swap.swap(logs[i]).Shutdown(ctx))
+	}
+
+	p.watcher.watch(targets)
+
+	err = errors.Join(err, closeTargets(p.targets))
+	p.targets = targets
+
+	return err
+}
+
+// withConnectionSettings returns the initial config with the endpoints, TLS, headers, compression and timeouts of
+// the collectors in cfg, so Reconfigure can't change how the exporters behave
+func withConnectionSettings(initial Config, cfg *Config) Config {
+	initial.OtelEndpoint = cfg.OtelEndpoint
+	initial.TraceEndpoint = cfg.TraceEndpoint
+	initial.MetricEndpoint = cfg.MetricEndpoint
+	initial.LogEndpoint = cfg.LogEndpoint
+	initial.Backends = cfg.Backends
+	initial.MetricRoutes = cfg.MetricRoutes
+
+	initial.TlsConfig = cfg.TlsConfig
+	initial.Insecure = cfg.Insecure
+	initial.TLSSkipVerify = cfg.TLSSkipVerify
+	initial.Headers = cfg.Headers
+	initial.Compression = cfg.Compression
+
+	initial.DialOptions = cfg.DialOptions
+	initial.DialTimeout = cfg.DialTimeout
+	initial.WaitForConnection = cfg.WaitForConnection
+	initial.WaitForReady = cfg.WaitForReady
+	initial.ExportTimeout = cfg.ExportTimeout
+
+	return initial
+}
+
+// swapSpanExporter delegates to an exporter that can be replaced. Replacing waits for in-flight exports
+type swapSpanExporter struct {
+	mu       sync.RWMutex
+	index    int
+	exporter sdktrace.SpanExporter
+}
+
+func (e *swapSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.ExportSpans(ctx, spans)
+}
+
+func (e *swapSpanExporter) Shutdown(ctx context.Context) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.Shutdown(ctx)
+}
+
+// swap replaces the exporter and returns the previous one
+func (e *swapSpanExporter) swap(exporter sdktrace.SpanExporter) sdktrace.SpanExporter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	old := e.exporter
+	e.exporter = exporter
+
+	return old
+}
+
+// swapMetricExporter delegates to an exporter that can be replaced. Replacing waits for in-flight exports
+type swapMetricExporter struct {
+	mu       sync.RWMutex
+	index    int
+	exporter sdkmetric.Exporter
+}
+
+func (e *swapMetricExporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.Temporality(kind)
+}
+
+func (e *swapMetricExporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.Aggregation(kind)
+}
+
+func (e *swapMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.Export(ctx, rm)
+}
+
+func (e *swapMetricExporter) ForceFlush(ctx context.Context) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.ForceFlush(ctx)
+}
+
+func (e *swapMetricExporter) Shutdown(ctx context.Context) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.Shutdown(ctx)
+}
+
+// swap replaces the exporter and returns the previous one
+func (e *swapMetricExporter) swap(exporter sdkmetric.Exporter) sdkmetric.Exporter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	old := e.exporter
+	e.exporter = exporter
+
+	return old
+}
+
+// swapLogExporter delegates to an exporter that can be replaced. Replacing waits for in-flight exports
+type swapLogExporter struct {
+	mu       sync.RWMutex
+	index    int
+	exporter sdklog.Exporter
+}
+
+func (e *swapLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.Export(ctx, records)
+}
+
+func (e *swapLogExporter) ForceFlush(ctx context.Context) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.ForceFlush(ctx)
+}
+
+func (e *swapLogExporter) Shutdown(ctx context.Context) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.exporter.Shutdown(ctx)
+}
+
+// swap replaces the exporter and returns the previous one
+func (e *swapLogExporter) swap(exporter sdklog.Exporter) sdklog.Exporter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	old := e.exporter
+	e.exporter = exporter
+
+	return old
+}
@@ -0,0 +1,108 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithConnectionSettings(t *testing.T) {
+	initial := Config{
+		OtelEndpoint:           "initial:4317",
+		Protocol:               ProtocolGRPC,
+		Headers:                map[string]string{"api-key": "initial"},
+		ExportTimeout:          time.Second,
+		Retry:                  &RetryConfig{},
+		CircuitBreaker:         &CircuitBreakerConfig{},
+		EnableSelfMetrics:      true,
+		NormalizeAttributeKeys: KeyConventionSnakeCase,
+		MetricTemporality:      TemporalityDelta,
+	}
+	cfg := &Config{
+		OtelEndpoint:  "next:4318",
+		Protocol:      ProtocolHTTP,
+		Headers:       map[string]string{"api-key": "next"},
+		Insecure:      true,
+		Compression:   "gzip",
+		DialTimeout:   2 * time.Second,
+		ExportTimeout: 3 * time.Second,
+	}
+
+	got := withConnectionSettings(initial, cfg)
+
+	want := initial
+	want.OtelEndpoint = cfg.OtelEndpoint
+	want.Headers = cfg.Headers
+	want.Insecure = cfg.Insecure
+	want.Compression = cfg.Compression
+	want.DialTimeout = cfg.DialTimeout
+	want.ExportTimeout = cfg.ExportTimeout
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("withConnectionSettings() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPipelineReconfigure(t *testing.T) {
+	first, second, third := startCollector(t), startCollector(t), startCollector(t)
+
+	tests := []struct {
+		name          string
+		cfg           *Config
+		wantErr       error
+		wantEndpoints []string
+	}{
+		{
+			name:          "repoints the collector",
+			cfg:           &Config{OtelEndpoint: second, Insecure: true},
+			wantEndpoints: []string{second},
+		},
+		{
+			name:          "keeps the targets on an additional backend",
+			cfg:           &Config{OtelEndpoint: second, Insecure: true, Backends: []Backend{{Endpoint: third}}},
+			wantErr:       TargetLayoutError{},
+			wantEndpoints: []string{first},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{OtelEndpoint: first, Insecure: true, EnableSelfMetrics: true}
+
+			targets, err := newTargets(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pipe := newPipeline(cfg, targets)
+			defer pipe.close()
+
+			exporter, err := newSpanExporter(context.Background(), cfg, targets[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			pipe.spanExporter(0, exporter)
+
+			err = pipe.reconfigure(context.Background(), tt.cfg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("reconfigure() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var endpoints []string
+			for _, target := range pipe.targets {
+				endpoints = append(endpoints, target.endpoint)
+			}
+			if !reflect.DeepEqual(endpoints, tt.wantEndpoints) {
+				t.Errorf("targets = %v, want %v", endpoints, tt.wantEndpoints)
+			}
+
+			if tt.wantErr == nil {
+				if _, ok := pipe.spans[0].exporter.(selfMetricsSpanExporter); !ok {
+					t.Errorf("span exporter = %T, want the initial self-metrics wrapper", pipe.spans[0].exporter)
+				}
+			}
+		})
+	}
+}
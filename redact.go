@@ -0,0 +1,99 @@
+package telemetry
+
+import (
+	"context"
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/otel/log"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// redactedValue replaces redacted attribute values and body matches
+const redactedValue = "[REDACTED]"
+
+// redactLogProcessor masks the values of the configured attribute keys and replaces matches of the patterns in log
+// bodies. Records are modified in place, so it must be registered before the processors that export them
+type redactLogProcessor struct {
+	keys     []string
+	patterns []*regexp.Regexp
+}
+
+func newRedactLogProcessor(keys []string, patterns []string) (*redactLogProcessor, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, RedactPatternError{err}
+		}
+
+		compiled = append(compiled, re)
+	}
+
+	return &redactLogProcessor{
+		keys:     keys,
+		patterns: compiled,
+	}, nil
+}
+
+func (p *redactLogProcessor) OnEmit(_ context.Context, record *sdklog.Record) error {
+	if len(p.patterns) > 0 {
+		record.SetBody(p.redactValue(record.Body()))
+	}
+
+	if len(p.keys) == 0 {
+		return nil
+	}
+
+	attrs := make([]log.KeyValue, 0, record.AttributesLen())
+	redacted := false
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		if slices.Contains(p.keys, kv.Key) {
+			kv.Value = log.StringValue(redactedValue)
+			redacted = true
+		}
+
+		attrs = append(attrs, kv)
+		return true
+	})
+
+	if redacted {
+		record.SetAttributes(attrs...)
+	}
+
+	return nil
+}
+
+// redactValue replaces pattern matches in strings, descending into slices and maps
+func (p *redactLogProcessor) redactValue(value log.Value) log.Value {
+	switch value.Kind() {
+	case log.KindString:
+		str := value.AsString()
+		for _, re := range p.patterns {
+			str = re.ReplaceAllLiteralString(str, redactedValue)
+		}
+
+		return log.StringValue(str)
+	case log.KindSlice:
+		values := slices.Clone(value.AsSlice())
+		for i := range values {
+			values[i] = p.redactValue(values[i])
+		}
+
+		return log.SliceValue(values...)
+	case log.KindMap:
+		kvs := slices.Clone(value.AsMap())
+		for i := range kvs {
+			kvs[i].Value = p.redactValue(kvs[i].Value)
+		}
+
+		return log.MapValue(kvs...)
+	default:
+		return value
+	}
+}
+
+func (p *redactLogProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *redactLogProcessor) ForceFlush(context.Context) error { return nil }
@@ -0,0 +1,114 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestSetupResourcePrecedence(t *testing.T) {
+	defaultServiceName := "unknown_service:" + filepath.Base(os.Args[0])
+
+	tests := []struct {
+		name     string
+		explicit bool
+		env      bool
+		file     bool
+		detector bool
+		priority []ResourceSource
+		key      attribute.Key
+		want     string
+	}{
+		{name: "explicit over every source", explicit: true, env: true, file: true, detector: true, key: "cloud.region", want: "explicit"},
+		{name: "env over file and detectors", env: true, file: true, detector: true, key: "cloud.region", want: "env"},
+		{name: "file over detectors", file: true, detector: true, key: "cloud.region", want: "file"},
+		{name: "detectors", detector: true, key: "cloud.region", want: "detector"},
+		{name: "default service name", key: "service.name", want: defaultServiceName},
+		{name: "explicit service name over default", explicit: true, key: "service.name", want: "explicit"},
+		{
+			name:     "custom priority",
+			explicit: true,
+			env:      true,
+			file:     true,
+			detector: true,
+			priority: []ResourceSource{ResourceSourceDetectors, ResourceSourceFile, ResourceSourceEnv, ResourceSourceExplicit},
+			key:      "cloud.region",
+			want:     "detector",
+		},
+		{
+			name:     "custom priority skips missing sources",
+			explicit: true,
+			env:      true,
+			file:     true,
+			priority: []ResourceSource{ResourceSourceEnv, ResourceSourceExplicit},
+			key:      "cloud.region",
+			want:     "env",
+		},
+		{
+			name:     "custom priority without explicit",
+			explicit: true,
+			priority: []ResourceSource{ResourceSourceDefault},
+			key:      "service.name",
+			want:     defaultServiceName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_SERVICE_NAME", "")
+			t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "")
+			t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+
+			cfg := &Config{ResourcePriority: tt.priority}
+
+			if tt.explicit {
+				cfg.ServiceName = "explicit"
+				cfg.ResourceAttributes = []attribute.KeyValue{attribute.String("cloud.region", "explicit")}
+			}
+
+			if tt.env {
+				t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "cloud.region=env")
+			}
+
+			if tt.file {
+				path := filepath.Join(t.TempDir(), "resource.json")
+				if err := os.WriteFile(path, []byte(`{"cloud": {"region": "file"}}`), 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				cfg.ResourceFile = path
+			}
+
+			if tt.detector {
+				t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "function")
+				t.Setenv("AWS_REGION", "detector")
+				cfg.Lambda = true
+			}
+
+			res, err := setupResource(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("setupResource() error = %v", err)
+			}
+
+			got, ok := res.Set().Value(tt.key)
+			if !ok {
+				t.Fatalf("resource has no %s attribute: %v", tt.key, res)
+			}
+
+			if got.Emit() != tt.want {
+				t.Errorf("%s = %q, want %q", tt.key, got.Emit(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupResourceInvalidPriority(t *testing.T) {
+	cfg := &Config{ResourcePriority: []ResourceSource{ResourceSourceEnv, ResourceSourceEnv}}
+
+	if _, err := setupResource(context.Background(), cfg); err == nil {
+		t.Fatal("setupResource() error = nil, want ResourcePriorityError")
+	}
+}
@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"gopkg.in/yaml.v3"
+)
+
+// resourceFromFile loads resource attributes from a JSON or YAML file. Nested objects are flattened into dotted keys,
+// so {"service": {"version": "1.2.0"}} becomes service.version=1.2.0
+func resourceFromFile(path string) (*resource.Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any)
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	if err := flattenAttributes("", values, &attrs); err != nil {
+		return nil, err
+	}
+
+	return resource.NewSchemaless(attrs...), nil
+}
+
+// flattenAttributes converts the decoded values into attributes, joining nested keys with dots
+func flattenAttributes(prefix string, values map[string]any, attrs *[]attribute.KeyValue) error {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flattenAttributes(key, v, attrs); err != nil {
+				return err
+			}
+		case string:
+			*attrs = append(*attrs, attribute.String(key, v))
+		case bool:
+			*attrs = append(*attrs, attribute.Bool(key, v))
+		case int:
+			*attrs = append(*attrs, attribute.Int(key, v))
+		case float64:
+			*attrs = append(*attrs, attribute.Float64(key, v))
+		case json.Number:
+			if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+				*attrs = append(*attrs, attribute.Int64(key, i))
+			} else if f, err := v.Float64(); err == nil {
+				*attrs = append(*attrs, attribute.Float64(key, f))
+			} else {
+				*attrs = append(*attrs, attribute.String(key, v.String()))
+			}
+		default:
+			return fmt.Errorf("unsupported value for resource attribute %s: %v", key, value)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestResourceFromFileNumbers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resource.json")
+	data := `{"host": {"cpus": 8, "load": 0.75, "memory": 1.5e9, "id": 18446744073709551616}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := resourceFromFile(path)
+	if err != nil {
+		t.Fatalf("resourceFromFile() error = %v", err)
+	}
+
+	tests := []struct {
+		key  attribute.Key
+		want attribute.Value
+	}{
+		{key: "host.cpus", want: attribute.Int64Value(8)},
+		{key: "host.load", want: attribute.Float64Value(0.75)},
+		{key: "host.memory", want: attribute.Float64Value(1.5e9)},
+		{key: "host.id", want: attribute.Float64Value(18446744073709551616)},
+	}
+
+	for _, tt := range tests {
+		got, ok := res.Set().Value(tt.key)
+		if !ok || got != tt.want {
+			t.Errorf("%s = %v (%v), want %v (%v)", tt.key, got.Emit(), got.Type(), tt.want.Emit(), tt.want.Type())
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+// ResourceSource is a source of resource attributes, see Config.ResourcePriority
+type ResourceSource int
+
+const (
+	// ResourceSourceExplicit is Config.ServiceName, Config.BuildInfo and Config.ResourceAttributes
+	ResourceSourceExplicit ResourceSource = iota + 1
+
+	// ResourceSourceEnv is OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME
+	ResourceSourceEnv
+
+	// ResourceSourceFile is Config.ResourceFile
+	ResourceSourceFile
+
+	// ResourceSourceDetectors are the enabled resource detectors, e.g. Config.Lambda
+	ResourceSourceDetectors
+
+	// ResourceSourceDefault is the SDK default resource, e.g. telemetry.sdk.* and a fallback service.name
+	ResourceSourceDefault
+)
+
+// DefaultResourcePriority is the order in which resource sources win when they set the same key, highest first
+var DefaultResourcePriority = []ResourceSource{
+	ResourceSourceExplicit,
+	ResourceSourceEnv,
+	ResourceSourceFile,
+	ResourceSourceDetectors,
+	ResourceSourceDefault,
+}
+
+func (s ResourceSource) String() string {
+	switch s {
+	case ResourceSourceExplicit:
+		return "explicit"
+	case ResourceSourceEnv:
+		return "env"
+	case ResourceSourceFile:
+		return "file"
+	case ResourceSourceDetectors:
+		return "detectors"
+	case ResourceSourceDefault:
+		return "default"
+	default:
+		return "unknown"
+	}
+}
+
+// resourcePriority returns the configured resource priority or the default, failing on unknown or repeated sources
+func resourcePriority(cfg *Config) ([]ResourceSource, error) {
+	if len(cfg.ResourcePriority) == 0 {
+		return DefaultResourcePriority, nil
+	}
+
+	seen := make(map[ResourceSource]bool, len(cfg.ResourcePriority))
+	for _, source := range cfg.ResourcePriority {
+		if source < ResourceSourceExplicit || source > ResourceSourceDefault || seen[source] {
+			return nil, ResourcePriorityError{source}
+		}
+
+		seen[source] = true
+	}
+
+	return cfg.ResourcePriority, nil
+}
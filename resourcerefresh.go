@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// resourceRefresher re-runs resource detection periodically for metric exporters of long lived processes whose
+// infrastructure attributes can change, e.g. a rescheduled pod
+type resourceRefresher struct {
+	current atomic.Pointer[resource.Resource]
+}
+
+func newResourceRefresher(initial *resource.Resource) *resourceRefresher {
+	refresher := &resourceRefresher{}
+	refresher.current.Store(initial)
+
+	return refresher
+}
+
+// run detects the resource every interval until the context is canceled. Failed detections keep the previous
+// resource
+func (r *resourceRefresher) run(ctx context.Context, cfg *Config, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			detected, err := setupResource(ctx, cfg)
+			if err != nil {
+				otel.Handle(SdkResourceError{err})
+				continue
+			}
+
+			r.current.Store(detected)
+		}
+	}
+}
+
+// exporter wraps the metric exporter so it exports with the latest detected resource
+func (r *resourceRefresher) exporter(exporter sdkmetric.Exporter) sdkmetric.Exporter {
+	if r == nil {
+		return exporter
+	}
+
+	return refreshResourceExporter{exporter, r}
+}
+
+// refreshResourceExporter replaces the resource of exported metrics with the latest detected resource
+type refreshResourceExporter struct {
+	sdkmetric.Exporter
+
+	refresher *resourceRefresher
+}
+
+func (e refreshResourceExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	refreshed := *rm
+	refreshed.Resource = e.refresher.current.Load()
+
+	return e.Exporter.Export(ctx, &refreshed)
+}
@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"cmp"
+	"time"
+)
+
+// Default retry settings of the OTLP exporters
+const (
+	defaultRetryInitialInterval = 5 * time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+	defaultRetryMaxElapsedTime  = time.Minute
+)
+
+// RetryConfig configures the exponential backoff of failed exports for every signal. Zero durations use the exporter
+// defaults of 5 seconds, 30 seconds and 1 minute
+type RetryConfig struct {
+	// Disabled drops a batch after its first failed export
+	Disabled bool
+
+	// InitialInterval is the wait after the first failure before retrying
+	InitialInterval time.Duration
+
+	// MaxInterval caps the wait between consecutive retries
+	MaxInterval time.Duration
+
+	// MaxElapsedTime bounds the time spent exporting a batch, including retries, after which it is dropped
+	MaxElapsedTime time.Duration
+}
+
+// exportRetry has the layout of the retry config of the OTLP exporters, so it converts to each of them
+type exportRetry struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// exportRetry returns the exporter retry config with defaults applied
+func (c *RetryConfig) exportRetry() exportRetry {
+	return exportRetry{
+		Enabled:         !c.Disabled,
+		InitialInterval: cmp.Or(c.InitialInterval, defaultRetryInitialInterval),
+		MaxInterval:     cmp.Or(c.MaxInterval, defaultRetryMaxInterval),
+		MaxElapsedTime:  cmp.Or(c.MaxElapsedTime, defaultRetryMaxElapsedTime),
+	}
+}
@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// routeMetricExporter exports only the named metrics when include is set, and every other metric otherwise
+type routeMetricExporter struct {
+	sdkmetric.Exporter
+
+	names   []string
+	include bool
+}
+
+func (e routeMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	filtered := &metricdata.ResourceMetrics{
+		Resource:     rm.Resource,
+		ScopeMetrics: make([]metricdata.ScopeMetrics, 0, len(rm.ScopeMetrics)),
+	}
+
+	for _, sm := range rm.ScopeMetrics {
+		metrics := make([]metricdata.Metrics, 0, len(sm.Metrics))
+		for _, m := range sm.Metrics {
+			if slices.Contains(e.names, m.Name) == e.include {
+				metrics = append(metrics, m)
+			}
+		}
+
+		if len(metrics) > 0 {
+			filtered.ScopeMetrics = append(filtered.ScopeMetrics, metricdata.ScopeMetrics{Scope: sm.Scope, Metrics: metrics})
+		}
+	}
+
+	if len(filtered.ScopeMetrics) == 0 {
+		return nil
+	}
+
+	return e.Exporter.Export(ctx, filtered)
+}
@@ -0,0 +1,129 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// recordingMetricExporter records the names of the exported metrics
+type recordingMetricExporter struct {
+	sdkmetric.Exporter
+
+	names []string
+}
+
+func (e *recordingMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			e.names = append(e.names, m.Name)
+		}
+	}
+
+	return nil
+}
+
+func TestValidateConfigMetricRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		routes  []MetricRoute
+		wantErr error
+	}{
+		{
+			name:   "named instruments",
+			routes: []MetricRoute{{Backend: Backend{Endpoint: "billing:4317"}, Instruments: []string{"orders"}}},
+		},
+		{
+			name:    "nil instruments",
+			routes:  []MetricRoute{{Backend: Backend{Endpoint: "billing:4317"}}},
+			wantErr: MetricRouteError{"billing:4317"},
+		},
+		{
+			name:    "empty instruments",
+			routes:  []MetricRoute{{Backend: Backend{Endpoint: "billing:4317"}, Instruments: []string{}}},
+			wantErr: MetricRouteError{"billing:4317"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(&Config{MetricRoutes: tt.routes})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateConfig() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTargetsMetricRoutes(t *testing.T) {
+	targets, err := newTargets(&Config{
+		OtelEndpoint: "collector:4318",
+		Protocol:     ProtocolHTTP,
+		MetricRoutes: []MetricRoute{{Backend: Backend{Endpoint: "billing:4318"}, Instruments: []string{"orders"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var routed []string
+	for _, target := range targets {
+		if target.metricsOnly() {
+			routed = append(routed, target.endpoint)
+		}
+	}
+
+	if want := []string{"billing:4318"}; !reflect.DeepEqual(routed, want) {
+		t.Errorf("routed targets = %v, want %v", routed, want)
+	}
+}
+
+func TestRouteMetricExporter(t *testing.T) {
+	rm := &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{
+			{Metrics: []metricdata.Metrics{{Name: "orders"}, {Name: "requests"}}},
+			{Metrics: []metricdata.Metrics{{Name: "latency"}}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		names   []string
+		include bool
+		want    []string
+	}{
+		{
+			name:    "route exports the named metrics",
+			names:   []string{"orders"},
+			include: true,
+			want:    []string{"orders"},
+		},
+		{
+			name:  "collector exports every other metric",
+			names: []string{"orders"},
+			want:  []string{"requests", "latency"},
+		},
+		{
+			name:    "nothing to export",
+			names:   []string{"unknown"},
+			include: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := &recordingMetricExporter{}
+
+			if err := (routeMetricExporter{exporter, tt.names, tt.include}).Export(context.Background(), rm); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(exporter.names, tt.want) {
+				t.Errorf("exported = %v, want %v", exporter.names, tt.want)
+			}
+		})
+	}
+}
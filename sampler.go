@@ -0,0 +1,158 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	remoteSamplerCacheSize = 1024
+	remoteSamplerTimeout   = 5 * time.Second
+)
+
+// SamplingSource provides sampling ratios from an external decision service
+type SamplingSource interface {
+	// SamplingRatio returns the fraction of root spans with the given name that should be sampled
+	SamplingRatio(ctx context.Context, spanName string) (float64, error)
+}
+
+// NewRemoteSampler returns a parent based sampler whose root span decisions use ratios from the source. Ratios are
+// cached per span name for the ttl and refreshed in the background, keeping the source off the span hot path. The
+// fallback sampler decides until a ratio is available or when the source returns an error
+func NewRemoteSampler(source SamplingSource, ttl time.Duration, fallback sdktrace.Sampler) sdktrace.Sampler {
+	return sdktrace.ParentBased(&remoteSampler{
+		source:   source,
+		ttl:      ttl,
+		fallback: fallback,
+		cache:    make(map[string]*remoteDecision),
+	})
+}
+
+type remoteDecision struct {
+	sampler    sdktrace.Sampler
+	expires    time.Time
+	refreshing bool
+}
+
+type remoteSampler struct {
+	source   SamplingSource
+	ttl      time.Duration
+	fallback sdktrace.Sampler
+
+	mu    sync.Mutex
+	cache map[string]*remoteDecision
+}
+
+func (s *remoteSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return s.sampler(p.Name).ShouldSample(p)
+}
+
+func (s *remoteSampler) Description() string {
+	return "RemoteSampler{fallback:" + s.fallback.Description() + "}"
+}
+
+// sampler returns the cached sampler for the span name, triggering a background refresh when it is missing or stale
+func (s *remoteSampler) sampler(name string) sdktrace.Sampler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	decision, ok := s.cache[name]
+	if !ok {
+		if len(s.cache) >= remoteSamplerCacheSize {
+			return s.fallback
+		}
+
+		decision = &remoteDecision{}
+		s.cache[name] = decision
+	}
+
+	if !decision.refreshing && time.Now().After(decision.expires) {
+		decision.refreshing = true
+		go s.refresh(name)
+	}
+
+	if decision.sampler == nil {
+		return s.fallback
+	}
+
+	return decision.sampler
+}
+
+// refresh queries the source for the span name and updates the cache
+func (s *remoteSampler) refresh(name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSamplerTimeout)
+	defer cancel()
+
+	ratio, err := s.source.SamplingRatio(ctx, name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	decision := s.cache[name]
+	decision.refreshing = false
+	decision.expires = time.Now().Add(s.ttl)
+
+	if err != nil {
+		decision.sampler = nil
+		return
+	}
+
+	decision.sampler = sdktrace.TraceIDRatioBased(ratio)
+}
+
+// debugKey is the baggage member and span attribute that forces sampling with DebugHeaderSampler
+const debugKey = "debug"
+
+// DebugHeaderSampler returns a sampler that samples every span whose context carries the debug=true baggage
+// member, e.g. from a "baggage: debug=true" request header, or that starts with the debug=true attribute, ignoring
+// case. Other spans are decided by the base sampler. Install it with Config.Sampler, extracting baggage requires
+// PropagatorBaggage in Config.Propagators
+func DebugHeaderSampler(base sdktrace.Sampler) sdktrace.Sampler {
+	return debugHeaderSampler{base}
+}
+
+type debugHeaderSampler struct {
+	base sdktrace.Sampler
+}
+
+func (s debugHeaderSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if !debugRequested(p) {
+		return s.base.ShouldSample(p)
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordAndSample,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (s debugHeaderSampler) Description() string {
+	return "DebugHeaderSampler{" + s.base.Description() + "}"
+}
+
+// debugRequested reports whether the parent baggage or the span attributes set the debug flag
+func debugRequested(p sdktrace.SamplingParameters) bool {
+	if isDebugValue(baggage.FromContext(p.ParentContext).Member(debugKey).Value()) {
+		return true
+	}
+
+	for _, attr := range p.Attributes {
+		if string(attr.Key) == debugKey && isDebugValue(attr.Value.Emit()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isDebugValue reports whether a baggage or attribute value sets the debug flag
+func isDebugValue(value string) bool {
+	return strings.EqualFold(value, "true")
+}
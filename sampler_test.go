@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestDebugHeaderSampler(t *testing.T) {
+	withBaggage := func(value string) context.Context {
+		member, err := baggage.NewMember(debugKey, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bag, err := baggage.New(member)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return baggage.ContextWithBaggage(context.Background(), bag)
+	}
+
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		attrs []attribute.KeyValue
+		want  sdktrace.SamplingDecision
+	}{
+		{name: "no flag", ctx: context.Background(), want: sdktrace.Drop},
+		{name: "baggage", ctx: withBaggage("true"), want: sdktrace.RecordAndSample},
+		{name: "baggage ignoring case", ctx: withBaggage("TRUE"), want: sdktrace.RecordAndSample},
+		{name: "baggage false", ctx: withBaggage("false"), want: sdktrace.Drop},
+		{name: "string attribute", ctx: context.Background(), attrs: []attribute.KeyValue{attribute.String(debugKey, "true")}, want: sdktrace.RecordAndSample},
+		{name: "string attribute ignoring case", ctx: context.Background(), attrs: []attribute.KeyValue{attribute.String(debugKey, "True")}, want: sdktrace.RecordAndSample},
+		{name: "bool attribute", ctx: context.Background(), attrs: []attribute.KeyValue{attribute.Bool(debugKey, true)}, want: sdktrace.RecordAndSample},
+	}
+
+	sampler := DebugHeaderSampler(sdktrace.NeverSample())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: tt.ctx,
+				TraceID:       trace.TraceID{0x01},
+				Name:          "request",
+				Attributes:    tt.attrs,
+			})
+
+			if result.Decision != tt.want {
+				t.Errorf("decision = %v, want %v", result.Decision, tt.want)
+			}
+		})
+	}
+}
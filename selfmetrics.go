@@ -0,0 +1,120 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// exporterMetrics counts the items handed to an exporter, following the SDK self-observability conventions. Failed
+// exports carry an error.type attribute
+type exporterMetrics struct {
+	exported metric.Int64Counter
+	attrs    []attribute.KeyValue
+}
+
+// newExporterMetrics creates the counter with the global meter provider, which delegates to the SDK provider once
+// it is registered
+func newExporterMetrics(name string, unit string, endpoint string) exporterMetrics {
+	counter, err := otel.Meter(instrumentationName).Int64Counter(name,
+		metric.WithUnit(unit),
+		metric.WithDescription("The number of items exported, including failed exports"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return exporterMetrics{
+		exported: counter,
+		attrs:    []attribute.KeyValue{semconv.ServerAddress(endpoint)},
+	}
+}
+
+func (m exporterMetrics) record(ctx context.Context, n int, err error) {
+	if m.exported == nil || n == 0 {
+		return
+	}
+
+	attrs := m.attrs
+	if err != nil {
+		attrs = append(attrs[:len(attrs):len(attrs)], semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	}
+
+	m.exported.Add(ctx, int64(n), metric.WithAttributes(attrs...))
+}
+
+type selfMetricsSpanExporter struct {
+	sdktrace.SpanExporter
+	metrics exporterMetrics
+}
+
+func (e selfMetricsSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.metrics.record(ctx, len(spans), err)
+
+	return err
+}
+
+type selfMetricsMetricExporter struct {
+	sdkmetric.Exporter
+	metrics exporterMetrics
+}
+
+func (e selfMetricsMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	err := e.Exporter.Export(ctx, rm)
+	e.metrics.record(ctx, countDataPoints(rm), err)
+
+	return err
+}
+
+type selfMetricsLogExporter struct {
+	sdklog.Exporter
+	metrics exporterMetrics
+}
+
+func (e selfMetricsLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	err := e.Exporter.Export(ctx, records)
+	e.metrics.record(ctx, len(records), err)
+
+	return err
+}
+
+// countDataPoints returns the number of data points in the resource metrics
+func countDataPoints(rm *metricdata.ResourceMetrics) int {
+	var n int
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch agg := m.Data.(type) {
+			case metricdata.Gauge[int64]:
+				n += len(agg.DataPoints)
+			case metricdata.Gauge[float64]:
+				n += len(agg.DataPoints)
+			case metricdata.Sum[int64]:
+				n += len(agg.DataPoints)
+			case metricdata.Sum[float64]:
+				n += len(agg.DataPoints)
+			case metricdata.Histogram[int64]:
+				n += len(agg.DataPoints)
+			case metricdata.Histogram[float64]:
+				n += len(agg.DataPoints)
+			case metricdata.ExponentialHistogram[int64]:
+				n += len(agg.DataPoints)
+			case metricdata.ExponentialHistogram[float64]:
+				n += len(agg.DataPoints)
+			case metricdata.Summary:
+				n += len(agg.DataPoints)
+			}
+		}
+	}
+
+	return n
+}
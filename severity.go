@@ -0,0 +1,41 @@
+package telemetry
+
+import (
+	"log/slog"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// severityMapper holds the mapper configured by InitProviders
+var severityMapper atomic.Pointer[SeverityMapper]
+
+// SeverityMapper maps a slog level to an OpenTelemetry log severity
+type SeverityMapper func(level slog.Level) log.Severity
+
+// DefaultSeverityMapper maps each slog level to the first severity of its OpenTelemetry range, so slog.LevelWarn
+// becomes log.SeverityWarn1 and slog.LevelError becomes log.SeverityError1. Levels between the slog constants map to
+// the higher severities of the range, e.g. slog.LevelWarn+1 becomes log.SeverityWarn2
+func DefaultSeverityMapper(level slog.Level) log.Severity {
+	// slog levels are spaced 4 apart like the OpenTelemetry ranges, with slog.LevelInfo at 0 and log.SeverityInfo1 at 9
+	severity := int(level) + int(log.SeverityInfo1)
+
+	switch {
+	case severity < int(log.SeverityTrace1):
+		return log.SeverityTrace1
+	case severity > int(log.SeverityFatal4):
+		return log.SeverityFatal4
+	default:
+		return log.Severity(severity)
+	}
+}
+
+// MapSeverity maps the slog level with the SeverityMapper configured by InitProviders, or DefaultSeverityMapper. Use
+// it to set the severity of records bridged from other loggers so they match the records of NewSlogHandler
+func MapSeverity(level slog.Level) log.Severity {
+	if configured := severityMapper.Load(); configured != nil && *configured != nil {
+		return (*configured)(level)
+	}
+
+	return DefaultSeverityMapper(level)
+}
@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestDefaultSeverityMapper(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  log.Severity
+	}{
+		{level: slog.LevelDebug, want: log.SeverityDebug1},
+		{level: slog.LevelInfo, want: log.SeverityInfo1},
+		{level: slog.LevelWarn, want: log.SeverityWarn1},
+		{level: slog.LevelWarn + 1, want: log.SeverityWarn2},
+		{level: slog.LevelError, want: log.SeverityError1},
+		{level: slog.LevelDebug - 100, want: log.SeverityTrace1},
+		{level: slog.LevelError + 100, want: log.SeverityFatal4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := DefaultSeverityMapper(tt.level); got != tt.want {
+				t.Errorf("DefaultSeverityMapper(%v) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapSeverity(t *testing.T) {
+	previous := severityMapper.Load()
+	t.Cleanup(func() { severityMapper.Store(previous) })
+
+	severityMapper.Store(nil)
+	if got := MapSeverity(slog.LevelWarn); got != log.SeverityWarn1 {
+		t.Errorf("MapSeverity() without a mapper = %v, want %v", got, log.SeverityWarn1)
+	}
+
+	var mapper SeverityMapper = func(slog.Level) log.Severity { return log.SeverityFatal }
+	severityMapper.Store(&mapper)
+
+	if got := MapSeverity(slog.LevelWarn); got != log.SeverityFatal {
+		t.Errorf("MapSeverity() with a mapper = %v, want %v", got, log.SeverityFatal)
+	}
+}
@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+// NewSlogHandler creates a slog.Handler that emits records through the logger provider in the context. Records logged
+// with a context carrying a span are correlated with it. Requires Config.EnableLogs
+func NewSlogHandler(ctx context.Context) (slog.Handler, error) {
+	logProvider, err := LogProviderFromContext(ctx)
+	if err != nil {
+		return nil, SlogHandlerError{err}
+	}
+
+	if logProvider == nil {
+		return nil, SlogHandlerError{LogProviderError{}}
+	}
+
+	var provider log.LoggerProvider = logProvider
+	if configured := severityMapper.Load(); configured != nil && *configured != nil {
+		provider = severityLoggerProvider{provider: provider, mapper: *configured}
+	}
+
+	return otelslog.NewHandler(instrumentationName, otelslog.WithLoggerProvider(provider)), nil
+}
+
+// NewSlogLogger creates a slog.Logger backed by NewSlogHandler
+func NewSlogLogger(ctx context.Context) (*slog.Logger, error) {
+	handler, err := NewSlogHandler(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slog.New(handler), nil
+}
+
+// severityLoggerProvider creates loggers that apply the configured SeverityMapper to bridged slog records
+type severityLoggerProvider struct {
+	embedded.LoggerProvider
+
+	provider log.LoggerProvider
+	mapper   SeverityMapper
+}
+
+func (p severityLoggerProvider) Logger(name string, opts ...log.LoggerOption) log.Logger {
+	return severityLogger{Logger: p.provider.Logger(name, opts...), mapper: p.mapper}
+}
+
+// severityLogger remaps the severity otelslog derives from the slog level
+type severityLogger struct {
+	log.Logger
+
+	mapper SeverityMapper
+}
+
+func (l severityLogger) Emit(ctx context.Context, record log.Record) {
+	record.SetSeverity(l.remap(record.Severity()))
+	l.Logger.Emit(ctx, record)
+}
+
+func (l severityLogger) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	if severity, ok := param.Severity(); ok {
+		param.SetSeverity(l.remap(severity))
+	}
+
+	return l.Logger.Enabled(ctx, param)
+}
+
+// remap recovers the slog level from the severity set by otelslog, which offsets levels like DefaultSeverityMapper
+func (l severityLogger) remap(severity log.Severity) log.Severity {
+	return l.mapper(slog.Level(int(severity) - int(log.SeverityInfo1)))
+}
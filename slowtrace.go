@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// maxSlowTraces bounds the number of unsampled local traces buffered at once
+	maxSlowTraces = 1024
+
+	// maxSlowTraceSpans bounds the number of spans buffered for a single trace
+	maxSlowTraceSpans = 512
+
+	// slowTraceMaxAgeFactor is the multiple of the threshold after which a trace whose root never ended is evicted
+	slowTraceMaxAgeFactor = 10
+)
+
+// slowTraceProcessor approximates tail sampling in process. Spans of unsampled local traces are held until the
+// local root ends, and the whole trace is exported if the root took at least threshold. Spans of faster traces, spans
+// ending after their root and spans over the buffer limits are passed on unchanged. When the buffer is full, traces
+// whose root started over slowTraceMaxAgeFactor thresholds ago are evicted so roots that never end don't hold it
+type slowTraceProcessor struct {
+	sdktrace.SpanProcessor
+
+	threshold time.Duration
+
+	mu        sync.Mutex
+	traces    map[trace.TraceID]*slowTrace
+	lastEvict time.Time
+}
+
+// slowTrace holds the ended spans of a trace until its local root ends
+type slowTrace struct {
+	started time.Time
+	spans   []sdktrace.ReadOnlySpan
+}
+
+func newSlowTraceProcessor(next sdktrace.SpanProcessor, threshold time.Duration) *slowTraceProcessor {
+	return &slowTraceProcessor{
+		SpanProcessor: next,
+		threshold:     threshold,
+		traces:        make(map[trace.TraceID]*slowTrace),
+	}
+}
+
+func (p *slowTraceProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	if !s.SpanContext().IsSampled() && isLocalRoot(s) {
+		var evicted []sdktrace.ReadOnlySpan
+
+		p.mu.Lock()
+		if _, ok := p.traces[s.SpanContext().TraceID()]; !ok {
+			if len(p.traces) >= maxSlowTraces {
+				evicted = p.evict(s.StartTime())
+			}
+
+			if len(p.traces) < maxSlowTraces {
+				p.traces[s.SpanContext().TraceID()] = &slowTrace{started: s.StartTime()}
+			}
+		}
+		p.mu.Unlock()
+
+		for _, span := range evicted {
+			p.SpanProcessor.OnEnd(span)
+		}
+	}
+
+	p.SpanProcessor.OnStart(ctx, s)
+}
+
+// evict removes the traces that started over the maximum age before now and returns their buffered spans. The buffer
+// is scanned at most once per threshold. The caller holds the lock
+func (p *slowTraceProcessor) evict(now time.Time) []sdktrace.ReadOnlySpan {
+	if now.Sub(p.lastEvict) < p.threshold {
+		return nil
+	}
+	p.lastEvict = now
+
+	maxAge := p.threshold * slowTraceMaxAgeFactor
+
+	var evicted []sdktrace.ReadOnlySpan
+	for traceID, buffered := range p.traces {
+		if now.Sub(buffered.started) >= maxAge {
+			delete(p.traces, traceID)
+			evicted = append(evicted, buffered.spans...)
+		}
+	}
+
+	return evicted
+}
+
+func (p *slowTraceProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.SpanProcessor.OnEnd(s)
+		return
+	}
+
+	traceID := s.SpanContext().TraceID()
+
+	p.mu.Lock()
+	buffered, ok := p.traces[traceID]
+	if !ok {
+		p.mu.Unlock()
+		p.SpanProcessor.OnEnd(s)
+		return
+	}
+
+	if !isLocalRoot(s) {
+		if len(buffered.spans) < maxSlowTraceSpans {
+			buffered.spans = append(buffered.spans, s)
+			p.mu.Unlock()
+			return
+		}
+
+		p.mu.Unlock()
+		p.SpanProcessor.OnEnd(s)
+		return
+	}
+
+	delete(p.traces, traceID)
+	p.mu.Unlock()
+
+	slow := s.EndTime().Sub(s.StartTime()) >= p.threshold
+	for _, span := range append(buffered.spans, s) {
+		if slow {
+			span = sampledSpan{span}
+		}
+
+		p.SpanProcessor.OnEnd(span)
+	}
+}
+
+// isLocalRoot reports whether the span has no parent in this process
+func isLocalRoot(s sdktrace.ReadOnlySpan) bool {
+	return !s.Parent().IsValid() || s.Parent().IsRemote()
+}
@@ -0,0 +1,41 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSlowTraceProcessorEvictsAbandonedRoots(t *testing.T) {
+	ctx := context.Background()
+	threshold := 10 * time.Millisecond
+
+	exporter := tracetest.NewInMemoryExporter()
+	processor := newSlowTraceProcessor(sdktrace.NewSimpleSpanProcessor(exporter), threshold)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(recordingSampler{sdktrace.NeverSample()}),
+		sdktrace.WithSpanProcessor(processor),
+	)
+	defer provider.Shutdown(ctx)
+
+	tracer := provider.Tracer("test")
+
+	// roots that never end fill the buffer
+	abandoned := time.Now().Add(-threshold * slowTraceMaxAgeFactor)
+	for range maxSlowTraces {
+		tracer.Start(ctx, "abandoned", trace.WithTimestamp(abandoned))
+	}
+
+	_, root := tracer.Start(ctx, "slow")
+	_, child := tracer.Start(trace.ContextWithSpan(ctx, root), "child")
+	child.End()
+	root.End(trace.WithTimestamp(time.Now().Add(threshold)))
+
+	if got := len(exporter.GetSpans()); got != 2 {
+		t.Errorf("exported %d spans, want the slow root and its child", got)
+	}
+}
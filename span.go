@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartSpan starts a span with the tracer in the context. A TracerError is returned when the context has no tracer
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span, error) {
+	tracer, err := TracerFromContext(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	ctx, span := tracer.Start(ctx, name, opts...)
+
+	return ctx, span, nil
+}
+
+// EndSpan records the error the pointer refers to, if any, with the ErrorStatusMapper and ends the span. It is meant to
+// be deferred with a named error result
+//
+//	func load(ctx context.Context) (err error) {
+//		ctx, span := tracer.Start(ctx, "load")
+//		defer telemetry.EndSpan(span, &err)
+func EndSpan(span trace.Span, err *error) {
+	if err != nil {
+		RecordError(span, *err)
+	}
+
+	span.End()
+}
+
+// WithSpan runs fn in a span with the tracer in the context, falling back to the global tracer provider. An error
+// returned by fn is recorded with the ErrorStatusMapper and returned, and the span is always ended
+func WithSpan(ctx context.Context, name string, fn func(context.Context) error, opts ...trace.SpanStartOption) (err error) {
+	tracer, tracerErr := TracerFromContext(ctx)
+	if tracerErr != nil {
+		tracer = globalTracer()
+	}
+
+	ctx, span := tracer.Start(ctx, name, opts...)
+	defer EndSpan(span, &err)
+
+	return fn(ctx)
+}
+
+// SpanUntilDone starts a span with the tracer in the context, falling back to the global tracer provider, and ends
+// it if the context is canceled or times out before the caller ends it. The status is set by the ErrorStatusMapper.
+// This prevents leaked unended spans from abandoned operations
+func SpanUntilDone(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	tracer, err := TracerFromContext(ctx)
+	if err != nil {
+		tracer = globalTracer()
+	}
+
+	ctx, span := tracer.Start(ctx, name, opts...)
+	if ctx.Done() == nil {
+		return ctx, span
+	}
+
+	guarded := &doneSpan{
+		Span: span,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			guarded.endOnce(func() {
+				setErrorStatus(guarded.Span, ctx.Err())
+				guarded.Span.End()
+			})
+		case <-guarded.done:
+		}
+	}()
+
+	return trace.ContextWithSpan(ctx, guarded), guarded
+}
+
+// doneSpan ends the wrapped span at most once and stops the context watcher when ended by the caller
+type doneSpan struct {
+	trace.Span
+
+	once sync.Once
+	done chan struct{}
+}
+
+func (s *doneSpan) End(opts ...trace.SpanEndOption) {
+	s.endOnce(func() {
+		s.Span.End(opts...)
+	})
+}
+
+func (s *doneSpan) endOnce(end func()) {
+	s.once.Do(func() {
+		end()
+		close(s.done)
+	})
+}
+
+// LinksFromContexts returns a link to the span context of every context, skipping contexts without a valid span
+// context. Starting a span with the links models fan-in, e.g. a batch aggregating several requests
+func LinksFromContexts(ctxs ...context.Context) []trace.Link {
+	links := make([]trace.Link, 0, len(ctxs))
+	for _, ctx := range ctxs {
+		if link := trace.LinkFromContext(ctx); link.SpanContext.IsValid() {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithSpan(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+	}{
+		{name: "error", err: errFailed, wantStatus: codes.Error},
+		{name: "success", err: nil, wantStatus: codes.Unset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer provider.Shutdown(context.Background())
+
+			ctx := AddTracerContext(context.Background(), provider.Tracer("test"))
+
+			err := WithSpan(ctx, "work", func(context.Context) error {
+				return tt.err
+			})
+			if err != tt.err {
+				t.Errorf("WithSpan() error = %v, want %v", err, tt.err)
+			}
+
+			// the syncer only exports ended spans
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("exported %d spans, want 1 ended span", len(spans))
+			}
+
+			if got := spans[0].Status.Code; got != tt.wantStatus {
+				t.Errorf("status = %v, want %v", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestEndSpan(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	load := func(ctx context.Context) (err error) {
+		_, span := provider.Tracer("test").Start(ctx, "load")
+		defer EndSpan(span, &err)
+
+		return errFailed
+	}
+
+	if err := load(context.Background()); err != errFailed {
+		t.Errorf("load() error = %v, want %v", err, errFailed)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1 ended span", len(spans))
+	}
+
+	if got := spans[0].Status; got.Code != codes.Error || got.Description != errFailed.Error() {
+		t.Errorf("status = %+v, want Error %q", got, errFailed.Error())
+	}
+
+	if len(spans[0].Events) != 1 || spans[0].Events[0].Name != "exception" {
+		t.Errorf("events = %+v, want a single exception event", spans[0].Events)
+	}
+}
@@ -0,0 +1,117 @@
+package telemetry
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recentSpans holds the debug span buffer of the active trace provider
+var recentSpans atomic.Pointer[spanBuffer]
+
+// RecentSpans returns the most recently ended spans, oldest first. Spans are recorded regardless of the sampling
+// decision when Config.DebugSpanBufferSize is set, otherwise nil is returned
+func RecentSpans() []sdktrace.ReadOnlySpan {
+	buffer := recentSpans.Load()
+	if buffer == nil {
+		return nil
+	}
+
+	return buffer.spans()
+}
+
+// spanBuffer is a span processor that keeps the last n ended spans of a fraction of traces in a ring buffer
+type spanBuffer struct {
+	mu    sync.Mutex
+	ring  []sdktrace.ReadOnlySpan
+	next  int
+	count int
+	bound uint64
+}
+
+// newSpanBuffer creates a buffer of size spans keeping the rate of traces, or every trace when the rate is 0
+func newSpanBuffer(size int, rate float64) (*spanBuffer, error) {
+	if rate < 0 || rate > 1 {
+		return nil, DebugSampleRateError{rate}
+	}
+
+	bound := uint64(math.MaxUint64)
+	if rate > 0 && rate < 1 {
+		bound = uint64(rate * math.MaxUint64)
+	}
+
+	return &spanBuffer{
+		ring:  make([]sdktrace.ReadOnlySpan, size),
+		bound: bound,
+	}, nil
+}
+
+func (b *spanBuffer) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (b *spanBuffer) OnEnd(s sdktrace.ReadOnlySpan) {
+	// every span of a trace is kept or dropped together. The trace ID is hashed instead of bucketed like
+	// TraceIDRatioBased, so the buffer keeps unsampled traces too when both use the same ratio
+	if traceHash(s.SpanContext().TraceID()) > b.bound {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.ring[b.next] = s
+	b.next = (b.next + 1) % len(b.ring)
+	if b.count < len(b.ring) {
+		b.count++
+	}
+}
+
+// traceHash hashes the whole trace ID with FNV-1a
+func traceHash(traceID trace.TraceID) uint64 {
+	h := fnv.New64a()
+	h.Write(traceID[:])
+
+	return h.Sum64()
+}
+
+func (b *spanBuffer) Shutdown(context.Context) error { return nil }
+
+func (b *spanBuffer) ForceFlush(context.Context) error { return nil }
+
+// spans returns a copy of the buffered spans, oldest first
+func (b *spanBuffer) spans() []sdktrace.ReadOnlySpan {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	spans := make([]sdktrace.ReadOnlySpan, 0, b.count)
+	start := (b.next - b.count + len(b.ring)) % len(b.ring)
+	for i := 0; i < b.count; i++ {
+		spans = append(spans, b.ring[(start+i)%len(b.ring)])
+	}
+
+	return spans
+}
+
+// recordingSampler records spans dropped by the wrapped sampler without sampling them, so span processors
+// observe them while exporters only receive sampled spans
+type recordingSampler struct {
+	sdktrace.Sampler
+}
+
+func (s recordingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := s.Sampler.ShouldSample(p)
+	if result.Decision == sdktrace.Drop {
+		result.Decision = sdktrace.RecordOnly
+	}
+
+	return result
+}
+
+func (s recordingSampler) Description() string {
+	return "RecordingSampler{" + s.Sampler.Description() + "}"
+}
@@ -0,0 +1,67 @@
+package telemetry
+
+import (
+	"errors"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSpanBufferRate(t *testing.T) {
+	tests := []struct {
+		rate    float64
+		wantErr bool
+	}{
+		{rate: 0},
+		{rate: 0.5},
+		{rate: 1},
+		{rate: -0.1, wantErr: true},
+		{rate: 1.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		_, err := newSpanBuffer(8, tt.rate)
+		if gotErr := errors.As(err, &DebugSampleRateError{}); gotErr != tt.wantErr {
+			t.Errorf("newSpanBuffer(%v) error = %v, want DebugSampleRateError %v", tt.rate, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSpanBufferIndependentOfSampler(t *testing.T) {
+	const traces = 1000
+
+	buffer, err := newSpanBuffer(traces, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sampler := sdktrace.TraceIDRatioBased(0.5)
+
+	var unsampled int
+	for i := range traces {
+		traceID := trace.TraceID{byte(i), byte(i >> 8), 0x5a, 0x01, 0x02, 0x03, 0x04, 0x05, byte(i * 7), byte(i * 13), byte(i >> 3), byte(i * 31), 0x06, 0x07, byte(i * 17), byte(i)}
+
+		result := sampler.ShouldSample(sdktrace.SamplingParameters{TraceID: traceID})
+		if result.Decision == sdktrace.Drop {
+			unsampled++
+		}
+
+		buffer.OnEnd(tracetest.SpanStub{
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{0x01}}),
+		}.Snapshot())
+	}
+
+	var keptUnsampled int
+	for _, s := range buffer.spans() {
+		result := sampler.ShouldSample(sdktrace.SamplingParameters{TraceID: s.SpanContext().TraceID()})
+		if result.Decision == sdktrace.Drop {
+			keptUnsampled++
+		}
+	}
+
+	if unsampled == 0 || keptUnsampled == 0 {
+		t.Errorf("buffer kept %d of %d unsampled traces, want some", keptUnsampled, unsampled)
+	}
+}
@@ -0,0 +1,209 @@
+package telemetry
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	packagePrefix = "github.com/nxdir-s/telemetry."
+	otelPrefix    = "go.opentelemetry.io/otel"
+	maxCallers    = 32
+)
+
+// codeAttributesProcessor attaches the source location of the code that started a span as code.function,
+// code.filepath and code.lineno attributes
+type codeAttributesProcessor struct{}
+
+func (codeAttributesProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	pcs := make([]uintptr, maxCallers)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+		if !isInstrumentationFrame(frame.Function) {
+			s.SetAttributes(
+				semconv.CodeFunction(frame.Function),
+				semconv.CodeFilepath(frame.File),
+				semconv.CodeLineNumber(frame.Line),
+			)
+			return
+		}
+
+		if !more {
+			return
+		}
+	}
+}
+
+func (codeAttributesProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (codeAttributesProcessor) Shutdown(context.Context) error { return nil }
+
+func (codeAttributesProcessor) ForceFlush(context.Context) error { return nil }
+
+// isInstrumentationFrame reports whether the function belongs to the OpenTelemetry SDK or this package
+func isInstrumentationFrame(function string) bool {
+	return strings.HasPrefix(function, otelPrefix) || strings.HasPrefix(function, packagePrefix)
+}
+
+const (
+	// maxErrorSignatures bounds the number of distinct error signatures tracked by the error quota processor per window
+	maxErrorSignatures = 1024
+
+	// errorQuotaWindow is how long the error quota processor counts signatures before the quotas are reset
+	errorQuotaWindow = time.Minute
+
+	// maxErrorDescriptionLen bounds the status description used in error signatures
+	maxErrorDescriptionLen = 64
+)
+
+// sampledSpan reports an unsampled span as sampled so it is exported by the batch span processor
+type sampledSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
+
+// errorQuotaProcessor exports unsampled error spans until quota spans of the same error signature have been seen in
+// the current window, so rare errors are kept under heavy sampling. Signatures combine the span name, the recorded
+// exception type and the status description with digits masked
+type errorQuotaProcessor struct {
+	sdktrace.SpanProcessor
+
+	quota  int
+	window time.Duration
+
+	mu          sync.Mutex
+	counts      map[string]int
+	windowStart time.Time
+}
+
+func newErrorQuotaProcessor(next sdktrace.SpanProcessor, quota int) *errorQuotaProcessor {
+	return &errorQuotaProcessor{
+		SpanProcessor: next,
+		quota:         quota,
+		window:        errorQuotaWindow,
+		counts:        make(map[string]int),
+		windowStart:   time.Now(),
+	}
+}
+
+func (p *errorQuotaProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Status().Code == codes.Error && !s.SpanContext().IsSampled() && p.claim(errorSignature(s)) {
+		s = sampledSpan{s}
+	}
+
+	p.SpanProcessor.OnEnd(s)
+}
+
+// claim counts an error span of the signature and reports whether it is within the quota of the current window
+func (p *errorQuotaProcessor) claim(signature string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if now := time.Now(); now.Sub(p.windowStart) >= p.window {
+		clear(p.counts)
+		p.windowStart = now
+	}
+
+	count, ok := p.counts[signature]
+	if !ok && len(p.counts) >= maxErrorSignatures {
+		return false
+	}
+
+	if count >= p.quota {
+		return false
+	}
+
+	p.counts[signature] = count + 1
+
+	return true
+}
+
+// errorSignature groups error spans by name, the type of the last recorded exception and the truncated status
+// description with digits masked, so IDs and durations in messages don't create new signatures
+func errorSignature(s sdktrace.ReadOnlySpan) string {
+	var exceptionType string
+
+	events := s.Events()
+	for i := len(events) - 1; i >= 0 && exceptionType == ""; i-- {
+		if events[i].Name != semconv.ExceptionEventName {
+			continue
+		}
+
+		for _, attr := range events[i].Attributes {
+			if attr.Key == semconv.ExceptionTypeKey {
+				exceptionType = attr.Value.Emit()
+			}
+		}
+	}
+
+	description := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return '#'
+		}
+
+		return r
+	}, s.Status().Description)
+
+	if len(description) > maxErrorDescriptionLen {
+		description = description[:maxErrorDescriptionLen]
+	}
+
+	return s.Name() + "|" + exceptionType + "|" + description
+}
+
+// eventsDroppedKey records how many events the event limit processor removed from a span
+const eventsDroppedKey = attribute.Key("telemetry.events_dropped")
+
+// eventLimitProcessor keeps the first max events of ended spans and records the overflow as telemetry.events_dropped,
+// so spans with runaway events are still exported instead of being rejected by the collector
+type eventLimitProcessor struct {
+	sdktrace.SpanProcessor
+
+	max int
+}
+
+func (p eventLimitProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if events := s.Events(); len(events) > p.max {
+		s = eventLimitSpan{s, events[:p.max], len(events) - p.max}
+	}
+
+	p.SpanProcessor.OnEnd(s)
+}
+
+// eventLimitSpan reports a truncated list of events
+type eventLimitSpan struct {
+	sdktrace.ReadOnlySpan
+
+	events  []sdktrace.Event
+	dropped int
+}
+
+func (s eventLimitSpan) Events() []sdktrace.Event {
+	return s.events
+}
+
+func (s eventLimitSpan) DroppedEvents() int {
+	return s.ReadOnlySpan.DroppedEvents() + s.dropped
+}
+
+func (s eventLimitSpan) Attributes() []attribute.KeyValue {
+	return append(slices.Clip(s.ReadOnlySpan.Attributes()), eventsDroppedKey.Int(s.dropped))
+}
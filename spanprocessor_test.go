@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// endedSpans records the spans passed to OnEnd
+type endedSpans struct {
+	sdktrace.SpanProcessor
+
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (p *endedSpans) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.spans = append(p.spans, s)
+}
+
+func errorSpan(sampled bool, description string, exceptionType string) sdktrace.ReadOnlySpan {
+	var flags trace.TraceFlags
+	if sampled {
+		flags = trace.FlagsSampled
+	}
+
+	stub := tracetest.SpanStub{
+		Name: "query",
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01},
+			SpanID:     trace.SpanID{0x01},
+			TraceFlags: flags,
+		}),
+		Status: sdktrace.Status{Code: codes.Error, Description: description},
+	}
+
+	if exceptionType != "" {
+		stub.Events = []sdktrace.Event{{
+			Name:       semconv.ExceptionEventName,
+			Attributes: []attribute.KeyValue{semconv.ExceptionType(exceptionType)},
+		}}
+	}
+
+	return stub.Snapshot()
+}
+
+func TestErrorQuotaProcessor(t *testing.T) {
+	next := &endedSpans{SpanProcessor: sdktrace.NewSimpleSpanProcessor(tracetest.NewNoopExporter())}
+	processor := newErrorQuotaProcessor(next, 1)
+
+	// sampled spans are exported anyway and don't use the quota
+	processor.OnEnd(errorSpan(true, "timeout after 10ms", ""))
+	processor.OnEnd(errorSpan(false, "timeout after 25ms", ""))
+	processor.OnEnd(errorSpan(false, "timeout after 40ms", ""))
+	processor.OnEnd(errorSpan(false, "connection 1 reset", "*net.OpError"))
+	processor.OnEnd(errorSpan(false, "connection 2 reset", "*net.OpError"))
+
+	var exported []bool
+	for _, s := range next.spans {
+		exported = append(exported, s.SpanContext().IsSampled())
+	}
+
+	want := []bool{true, true, false, true, false}
+	if !slices.Equal(exported, want) {
+		t.Errorf("sampled = %v, want %v", exported, want)
+	}
+
+	processor.window = time.Nanosecond
+	time.Sleep(time.Millisecond)
+
+	next.spans = nil
+	processor.OnEnd(errorSpan(false, "timeout after 55ms", ""))
+
+	if len(next.spans) != 1 || !next.spans[0].SpanContext().IsSampled() {
+		t.Error("quota was not reset after the window")
+	}
+}
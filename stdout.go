@@ -0,0 +1,181 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/log"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// StdoutFormatPretty writes indented JSON
+	StdoutFormatPretty = "pretty"
+
+	// StdoutFormatJSON writes one compact JSON object per line
+	StdoutFormatJSON = "json"
+
+	// StdoutFormatLogfmt writes one logfmt line per span or log record
+	StdoutFormatLogfmt = "logfmt"
+)
+
+// newStdoutLogExporter creates an exporter writing log records to w in the format, defaulting to pretty JSON
+func newStdoutLogExporter(format string, w io.Writer) (sdklog.Exporter, error) {
+	switch format {
+	case "", StdoutFormatPretty:
+		return stdoutlog.New(stdoutlog.WithWriter(w), stdoutlog.WithPrettyPrint())
+	case StdoutFormatJSON:
+		return stdoutlog.New(stdoutlog.WithWriter(w))
+	case StdoutFormatLogfmt:
+		return &logfmtLogExporter{w: w}, nil
+	default:
+		return nil, StdoutFormatError{format}
+	}
+}
+
+// newStdoutSpanExporter creates an exporter writing spans to w in the format, defaulting to pretty JSON
+func newStdoutSpanExporter(format string, w io.Writer) (sdktrace.SpanExporter, error) {
+	switch format {
+	case "", StdoutFormatPretty:
+		return stdouttrace.New(stdouttrace.WithWriter(w), stdouttrace.WithPrettyPrint())
+	case StdoutFormatJSON:
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	case StdoutFormatLogfmt:
+		return &logfmtSpanExporter{w: w}, nil
+	default:
+		return nil, StdoutFormatError{format}
+	}
+}
+
+// newStdoutMetricExporter creates an exporter writing metrics to w in the format, defaulting to pretty JSON. Metrics
+// have no logfmt representation and are written as compact JSON instead
+func newStdoutMetricExporter(format string, w io.Writer, temporality sdkmetric.TemporalitySelector) (sdkmetric.Exporter, error) {
+	opts := []stdoutmetric.Option{
+		stdoutmetric.WithWriter(w),
+		stdoutmetric.WithTemporalitySelector(temporality),
+	}
+
+	switch format {
+	case "", StdoutFormatPretty:
+		return stdoutmetric.New(append(opts, stdoutmetric.WithPrettyPrint())...)
+	case StdoutFormatJSON, StdoutFormatLogfmt:
+		return stdoutmetric.New(opts...)
+	default:
+		return nil, StdoutFormatError{format}
+	}
+}
+
+// logfmtLogExporter writes every log record as a single logfmt line
+type logfmtLogExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (e *logfmtLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	var b strings.Builder
+	for _, record := range records {
+		timestamp := record.Timestamp()
+		if timestamp.IsZero() {
+			timestamp = record.ObservedTimestamp()
+		}
+
+		writeLogfmt(&b, "time", timestamp.Format(time.RFC3339Nano))
+		b.WriteByte(' ')
+		writeLogfmt(&b, "severity", record.Severity().String())
+		b.WriteByte(' ')
+		writeLogfmt(&b, "body", record.Body().String())
+
+		if record.TraceID().IsValid() {
+			b.WriteByte(' ')
+			writeLogfmt(&b, "trace_id", record.TraceID().String())
+			b.WriteByte(' ')
+			writeLogfmt(&b, "span_id", record.SpanID().String())
+		}
+
+		record.WalkAttributes(func(kv log.KeyValue) bool {
+			b.WriteByte(' ')
+			writeLogfmt(&b, kv.Key, kv.Value.String())
+			return true
+		})
+
+		b.WriteByte('\n')
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, err := io.WriteString(e.w, b.String())
+
+	return err
+}
+
+func (e *logfmtLogExporter) Shutdown(context.Context) error { return nil }
+
+func (e *logfmtLogExporter) ForceFlush(context.Context) error { return nil }
+
+// logfmtSpanExporter writes every span as a single logfmt line
+type logfmtSpanExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (e *logfmtSpanExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var b strings.Builder
+	for _, span := range spans {
+		writeLogfmt(&b, "time", span.StartTime().Format(time.RFC3339Nano))
+		b.WriteByte(' ')
+		writeLogfmt(&b, "span", span.Name())
+		b.WriteByte(' ')
+		writeLogfmt(&b, "duration", span.EndTime().Sub(span.StartTime()).String())
+		b.WriteByte(' ')
+		writeLogfmt(&b, "status", span.Status().Code.String())
+		b.WriteByte(' ')
+		writeLogfmt(&b, "trace_id", span.SpanContext().TraceID().String())
+		b.WriteByte(' ')
+		writeLogfmt(&b, "span_id", span.SpanContext().SpanID().String())
+
+		if span.Parent().IsValid() {
+			b.WriteByte(' ')
+			writeLogfmt(&b, "parent_id", span.Parent().SpanID().String())
+		}
+
+		for _, attr := range span.Attributes() {
+			b.WriteByte(' ')
+			writeLogfmt(&b, string(attr.Key), attr.Value.Emit())
+		}
+
+		b.WriteByte('\n')
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, err := io.WriteString(e.w, b.String())
+
+	return err
+}
+
+func (e *logfmtSpanExporter) Shutdown(context.Context) error { return nil }
+
+// writeLogfmt writes key=value, quoting values that contain spaces, quotes or equal signs
+func writeLogfmt(b *strings.Builder, key string, value string) {
+	b.WriteString(key)
+	b.WriteByte('=')
+
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		b.WriteString(strconv.Quote(value))
+		return
+	}
+
+	b.WriteString(value)
+}
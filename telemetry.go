@@ -4,189 +4,1050 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
-	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
-	lambdadetector "go.opentelemetry.io/contrib/detectors/aws/lambda"
-	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	instrumentationName = "github.com/nxdir-s/telemetry"
+
+	defaultMetricInterval  = 1 * time.Second
+	defaultShutdownTimeout = 5 * time.Second
+
+	tracesExporterEnv  = "OTEL_TRACES_EXPORTER"
+	metricsExporterEnv = "OTEL_METRICS_EXPORTER"
+	logsExporterEnv    = "OTEL_LOGS_EXPORTER"
+	minLogSeverityEnv  = "TELEMETRY_MIN_LOG_SEVERITY"
 )
 
 type TracerCtxKey struct{}
 type MeterCtxKey struct{}
 type LoggerCtxKey struct{}
+type ResourceCtxKey struct{}
+type SpanAttributesCtxKey struct{}
+type DebugRequestCtxKey struct{}
 
 type ShutdownFuncs []func(context.Context) error
-type CleanupFunc func(context.Context)
+
+// CleanupFunc flushes and shuts down the providers, returning every shutdown error joined
+type CleanupFunc func(context.Context) error
+
+// Backend is an additional OTLP collector endpoint with its own TLS configuration and authentication headers
+type Backend struct {
+	Endpoint  string
+	TlsConfig *tls.Config
+	Headers   map[string]string
+}
+
+// MetricRoute exports the named metric instruments to a dedicated backend, e.g. a long retention store for billing
+// metrics. Routed instruments are not exported to the default collectors. A route must name at least one instrument
+type MetricRoute struct {
+	Backend
+
+	Instruments []string
+}
 
 type Config struct {
 	ServiceName  string
 	OtelEndpoint string
 	TlsConfig    *tls.Config
 	Lambda       bool
+
+	// ECS, EC2 and EKS add the attributes of the AWS platform the process runs on to the resource, like Lambda.
+	// Detection failures fail initialization
+	ECS bool
+	EC2 bool
+	EKS bool
+
+	// GCP adds the attributes of the Google Cloud platform the process runs on to the resource, e.g. GCE, GKE or
+	// Cloud Run
+	GCP bool
+
+	// HostDetection adds the host name, OS and process attributes such as the PID to the resource. Not affected by
+	// AutoDetect
+	HostDetection bool
+
+	// AutoDetect runs every resource detector and skips the ones that do not apply. The EC2 detector queries the
+	// instance metadata service, which delays initialization outside of EC2 until its request times out
+	AutoDetect bool
+
+	// Disabled makes InitProviders register no-op providers without connecting to a collector, e.g. in unit tests.
+	// The context still carries a tracer and meter, and the logger provider when EnableLogs is set
+	Disabled bool
+
+	// TraceEndpoint, MetricEndpoint and LogEndpoint override OtelEndpoint for a single signal, e.g. to export metrics
+	// to a different collector than traces. Each distinct endpoint gets its own connection, signals without an
+	// override share the OtelEndpoint connection. Over HTTP, an override given as a URL is used as is
+	TraceEndpoint  string
+	MetricEndpoint string
+	LogEndpoint    string
+
+	// Headers are sent with every export to the OtelEndpoint and signal endpoints, e.g. the API key of a managed
+	// collector. Backends and metric routes set their own headers
+	Headers map[string]string
+
+	// Insecure connects to every collector over plaintext, e.g. a local collector during development. Connections
+	// without a TLS config are plaintext as well
+	Insecure bool
+
+	// TLSSkipVerify disables verification of collector certificates, e.g. a self signed collector. For development
+	// only, a warning is logged whenever connections are created with it
+	TLSSkipVerify bool
+
+	// ResourceAttributes are added to the resource and take precedence over every other source, including
+	// OTEL_RESOURCE_ATTRIBUTES
+	ResourceAttributes []attribute.KeyValue
+
+	// ResourceFile is an optional JSON or YAML file of resource attributes. Environment variables take precedence
+	// over the file
+	ResourceFile string
+
+	// ResourcePriority orders the resource sources by precedence, highest first, for keys set by several sources.
+	// Sources missing from the list are not used. Defaults to DefaultResourcePriority
+	ResourcePriority []ResourceSource
+
+	// BuildInfo records the version, commit, commit time and build time as resource attributes, so every span,
+	// metric and log record identifies the build that produced it
+	BuildInfo *BuildInfo
+
+	// ResourceRefreshInterval re-runs resource detection at this interval and exports metrics with the refreshed
+	// resource, for long lived processes on infrastructure whose attributes change. Spans and logs keep the initial
+	// resource
+	ResourceRefreshInterval time.Duration
+
+	// OnResourceConflict resolves resources with conflicting schema URLs while merging detector, environment and
+	// config resources, b being the resource that takes precedence. When nil the conflict fails initialization
+	OnResourceConflict func(a, b *resource.Resource) (*resource.Resource, error)
+
+	// Exporter selects where telemetry is exported, ExporterOTLP or ExporterStdout. Defaults to OTLP. The stdout
+	// exporter writes spans, metrics and logs to stderr in the StdoutFormat for local development, without connecting
+	// to any collector
+	Exporter string
+
+	// Protocol selects the OTLP transport, ProtocolGRPC or ProtocolHTTP. Defaults to gRPC. HTTP endpoints may be
+	// given as host:port or as a URL such as https://host:4318/v1/traces, and the path of each signal is derived from
+	// it unless the URL is a signal endpoint. DialTimeout, WaitForConnection and WaitForReady only apply to gRPC
+	Protocol string
+
+	// Compression selects the compressor of every exporter, CompressionGzip or CompressionNone. Defaults to the
+	// compression of the Profile, which is none unless a profile is set
+	Compression string
+
+	// Profile applies a preset of batching, compression and sampling settings, see ProfileLowLatency,
+	// ProfileHighThroughput and ProfileLowCost. Explicitly configured fields take precedence
+	Profile string
+
+	// DialOptions are added to every collector connection after the transport credentials, DialTimeout and
+	// WaitForReady, and override them where they conflict, e.g. a keepalive policy or client interceptors. gRPC only
+	DialOptions []grpc.DialOption
+
+	// BatchSpan tunes span batching, e.g. a larger queue for services that drop spans under burst load. Set fields
+	// take precedence over the Profile
+	BatchSpan BatchSpanConfig
+
+	// DialTimeout bounds each attempt to establish the collector connection. Defaults to the gRPC minimum connect timeout
+	DialTimeout time.Duration
+
+	// WaitForConnection connects to every gRPC collector during initialization and fails with a GrpcConnError when a
+	// connection is not ready within DialTimeout, 20 seconds by default. Connections are established lazily otherwise
+	WaitForConnection bool
+
+	// ExportTimeout bounds each export RPC once connected. Defaults to the exporter timeout
+	ExportTimeout time.Duration
+
+	// Retry tunes the backoff of failed exports or disables retries. Defaults to retrying with the exporter backoff
+	Retry *RetryConfig
+
+	// WaitForReady makes export RPCs wait for the collector connection to become ready instead of failing fast while
+	// it is unavailable. Exports are still bounded by ExportTimeout
+	WaitForReady bool
+
+	// TraceHeaders continues traces from legacy upstreams that send trace context in nonstandard headers
+	TraceHeaders *TraceHeaders
+
+	// Propagators names the propagators used in both directions, see PropagatorTraceContext, PropagatorBaggage,
+	// PropagatorB3, PropagatorB3Multi and PropagatorXRay. Defaults to W3C trace context and X-Ray
+	Propagators []string
+
+	// InjectPropagators replace Propagators when injecting into outgoing requests
+	InjectPropagators []propagation.TextMapPropagator
+
+	// ExtractPropagators replace Propagators when extracting from incoming requests, e.g. to accept a legacy format
+	// that is no longer injected
+	ExtractPropagators []propagation.TextMapPropagator
+
+	// Sampler decides which spans are sampled. Takes precedence over SampleRatio and the profile, and defaults to
+	// sampling every span
+	Sampler sdktrace.Sampler
+
+	// SampleRatio samples this fraction of new traces with ParentBased(TraceIDRatioBased(ratio)), so child spans
+	// follow the sampling decision of their parent, including upstream services. Must be between 0 and 1, where 0
+	// leaves it unset
+	SampleRatio float64
+
+	// RecordCodeAttributes attaches code.function, code.filepath and code.lineno attributes of the caller to every
+	// started span. Off by default since walking the stack on every span start adds overhead
+	RecordCodeAttributes bool
+
+	// EnableAudit exports spans and log records marked with AuditAttribute or AuditLogAttribute synchronously,
+	// retrying until AuditTimeout instead of batching them best-effort. Audit spans are always sampled
+	EnableAudit bool
+
+	// AuditTimeout bounds the retries of a single audit export. Defaults to 30 seconds
+	AuditTimeout time.Duration
+
+	// ErrorSampleQuota exports the first n error spans per minute of every distinct error signature, the span name,
+	// exception type and status description with digits masked, even when they were not sampled. Spans dropped by
+	// the sampler are still recorded so their status can be inspected when they end
+	ErrorSampleQuota int
+
+	// MaxSpanEvents keeps the first n events of every exported span and records how many were removed in the
+	// telemetry.events_dropped attribute
+	MaxSpanEvents int
+
+	// DebugSpanBufferSize keeps the last n ended spans in memory, including unsampled ones, see RecentSpans
+	DebugSpanBufferSize int
+
+	// DebugSampleRate is the fraction of traces kept in the debug span buffer, between 0 and 1. Whole traces are kept
+	// or dropped by a hash of the trace ID, independent of the sampler. Defaults to keeping every trace, see
+	// DebugHandler
+	DebugSampleRate float64
+
+	// KeepSlowTracesOver exports unsampled local traces whose root span took at least this long, approximating tail
+	// sampling in process. Spans of unsampled traces are recorded and held until their local root ends
+	KeepSlowTracesOver time.Duration
+
+	// ErrorStatusMapper maps errors to span statuses in RecordError and SpanUntilDone. Defaults to
+	// DefaultErrorStatusMapper
+	ErrorStatusMapper ErrorStatusMapper
+
+	// DefaultSpanKind is applied to spans started with the tracer in the context unless the caller sets a kind
+	DefaultSpanKind trace.SpanKind
+
+	// DefaultSpanStartOptions are applied to spans started with the tracer in the context before the options of the
+	// caller, which take precedence
+	DefaultSpanStartOptions []trace.SpanStartOption
+
+	// Instruments is an optional manifest of expected metric instruments. Creating an instrument that is not in the
+	// manifest, or differs in unit or kind, returns an InstrumentMismatchError. See ValidateInstruments
+	Instruments []InstrumentSpec
+
+	// TraceInit emits a telemetry.init span once the trace provider is up, timing resource detection, connection
+	// setup and exporter creation. Connections are established lazily, so dial only covers creating the clients
+	TraceInit bool
+
+	// BeforeShutdown runs during cleanup after the providers are flushed and before they shut down. Metrics recorded
+	// by the hook are exported by the final flush on shutdown. The context carries the tracer and meter
+	BeforeShutdown func(ctx context.Context)
+
+	// ShutdownTimeout bounds each flush and shutdown call made by the cleanup function, so an unreachable collector
+	// cannot block process exit. Defaults to 5 seconds
+	ShutdownTimeout time.Duration
+
+	// SeverityMapper converts slog levels to OpenTelemetry severities in NewSlogHandler and MapSeverity. Defaults to
+	// DefaultSeverityMapper
+	SeverityMapper SeverityMapper
+
+	// MinLogSeverity drops log records below the severity before they are exported, e.g. log.SeverityWarn1 to only
+	// ship warnings and errors. Overridden by the TELEMETRY_MIN_LOG_SEVERITY environment variable. Defaults to
+	// exporting every record
+	MinLogSeverity log.Severity
+
+	// MaxDebugRequests is the number of requests that may be marked with WithDebugRequest, which adds the high
+	// cardinality request.id attribute to their metrics. Defaults to 0, which disables request debugging
+	MaxDebugRequests int
+
+	// EnableTraces and EnableMetrics create the trace and meter providers and add a tracer and meter to the context.
+	// A disabled signal has no provider and its context getter returns an error. Both default to true
+	EnableTraces  *bool
+	EnableMetrics *bool
+
+	// EnableLogs creates a logger provider exporting over the collector connections and adds it to the context, see
+	// LogProviderFromContext. Feature still in BETA
+	EnableLogs bool
+
+	// LogToStdout writes a human readable copy of every log record to stdout alongside the OTLP export
+	LogToStdout bool
+
+	// StdoutFormat selects the stdout output, see StdoutFormatPretty, StdoutFormatJSON and StdoutFormatLogfmt.
+	// Defaults to pretty JSON
+	StdoutFormat string
+
+	// LogsFollowTraceSampling drops log records emitted within unsampled traces, tying log volume to trace sampling
+	LogsFollowTraceSampling bool
+
+	// RedactLogAttributes are log attribute keys whose values are replaced before export
+	RedactLogAttributes []string
+
+	// RedactLogPatterns are regular expressions replaced in log bodies before export, e.g. email addresses or card
+	// numbers
+	RedactLogPatterns []string
+
+	// Backends are additional collectors that receive a copy of all exported telemetry, e.g. during a backend migration
+	Backends []Backend
+
+	// FanOut controls whether exports to the primary collector and Backends run concurrently or in order, see FanOut
+	FanOut FanOut
+
+	// NormalizeAttributeKeys rewrites span and metric attribute keys to a naming convention before export, see
+	// KeyConventionSnakeCase
+	NormalizeAttributeKeys string
+
+	// MetricRoutes send the named metric instruments to dedicated backends instead of the default collectors
+	MetricRoutes []MetricRoute
+
+	// CircuitBreaker stops export attempts after consecutive failures, dropping telemetry locally until a periodic
+	// probe succeeds. Disabled when nil
+	CircuitBreaker *CircuitBreakerConfig
+
+	// CollectorHealthMetric reports a telemetry_collector_up gauge that is 1 while the connection to each collector
+	// is ready and 0 otherwise
+	CollectorHealthMetric bool
+
+	// EnableSelfMetrics reports the number of spans, metric data points and log records handed to each exporter as
+	// otel.sdk.exporter.*.exported counters, with an error.type attribute for failed exports
+	EnableSelfMetrics bool
+
+	// MetricInterval is the interval between metric exports. Overrides the profile and defaults to 1 second
+	MetricInterval time.Duration
+
+	// MetricExportTimeout bounds each collection and export of the periodic metric reader. Defaults to the SDK
+	// default of 30 seconds
+	MetricExportTimeout time.Duration
+
+	// ExemplarReservoirs sets the number of exemplars kept per data point of the named instruments, e.g. to link more
+	// traces to a hot histogram than the default of one exemplar per bucket
+	ExemplarReservoirs map[string]int
+
+	// HistogramBuckets replaces the default explicit bucket boundaries of the named histograms, e.g. to match SLO
+	// latency thresholds
+	HistogramBuckets map[string][]float64
+
+	// Views are additional metric views. An instrument matched by several views, including the views of
+	// ExemplarReservoirs and HistogramBuckets, is exported once per view
+	Views []sdkmetric.View
+
+	// MetricTemporality selects the temporality of exported metrics, see TemporalityCumulative, TemporalityDelta and
+	// TemporalityLowMemory
+	MetricTemporality string
 }
 
-// InitProviders initializes trace and metric providers, and adds a tracer and meter to the context
+// InitProviders initializes trace and metric providers, and adds a tracer and meter to the context, unless disabled by
+// Config.EnableTraces or Config.EnableMetrics. The logger provider is added as well when Config.EnableLogs is set. A
+// signal whose OTEL_TRACES_EXPORTER, OTEL_METRICS_EXPORTER or OTEL_LOGS_EXPORTER environment variable is set to "none"
+// is skipped entirely
 func InitProviders(ctx context.Context, cfg *Config) (context.Context, CleanupFunc, error) {
+	ctx, providers, err := NewProviders(ctx, cfg)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	return ctx, providers.Shutdown, nil
+}
+
+// NewProviders initializes the providers like InitProviders and returns them, for direct access to the SDK providers,
+// e.g. to register an additional span processor. Providers.Shutdown replaces the cleanup function
+func NewProviders(ctx context.Context, cfg *Config) (context.Context, *Providers, error) {
+	providers := &Providers{timeout: shutdownTimeout(cfg)}
 	shutdown := make(ShutdownFuncs, 0, 2)
+	flush := make(ShutdownFuncs, 0, 2)
+	timings := newInitTimings()
+
+	if cfg.Disabled {
+		return initNoopProviders(ctx, cfg)
+	}
+
+	if err := validateConfig(cfg); err != nil {
+		return ctx, nil, err
+	}
 
 	resource, err := setupResource(ctx, cfg)
 	if err != nil {
 		return ctx, nil, SdkResourceError{err}
 	}
 
-	grpcClient, err := grpc.NewClient(cfg.OtelEndpoint, grpc.WithTransportCredentials(credentials.NewTLS(cfg.TlsConfig)))
-	if err != nil {
-		return ctx, nil, GrpcConnError{err}
-	}
+	ctx = context.WithValue(ctx, ResourceCtxKey{}, resource)
+	timings.phase("resource_detection")
 
-	traceProvider, err := setupTraceProvider(ctx, grpcClient, resource)
+	targets, err := newTargets(cfg)
 	if err != nil {
 		return ctx, nil, err
 	}
-	shutdown = append(shutdown, traceProvider.Shutdown)
 
-	meterProvider, err := setupMeterProvider(ctx, grpcClient, resource)
-	if err != nil {
+	pipe := newPipeline(cfg, targets)
+	timings.phase("dial")
+
+	// fail unwinds a partial init, shutting down the providers created so far before closing the targets
+	fail := func(err error) (context.Context, *Providers, error) {
+		cleanupCtx := context.WithoutCancel(ctx)
+
+		var cleanupErr error
+		for _, fn := range shutdown {
+			cleanupErr = errors.Join(cleanupErr, callWithTimeout(cleanupCtx, providers.timeout, fn))
+		}
+
+		if cleanupErr = errors.Join(cleanupErr, closeTargets(targets)); cleanupErr != nil {
+			err = errors.Join(err, cleanupErr)
+		}
+
 		return ctx, nil, err
 	}
-	shutdown = append(shutdown, meterProvider.Shutdown)
 
-	tracer := traceProvider.Tracer(cfg.ServiceName)
-	meter := meterProvider.Meter(cfg.ServiceName)
+	var initTracer trace.Tracer
+	var register []func()
+
+	if enabled(cfg.EnableTraces) && exporterEnabled(tracesExporterEnv) {
+		traceProvider, registerTraces, err := setupTraceProvider(ctx, cfg, pipe, resource)
+		if err != nil {
+			return fail(err)
+		}
+		register = append(register, registerTraces)
+
+		shutdown = append(shutdown, traceProvider.Shutdown)
+		flush = append(flush, traceProvider.ForceFlush)
+		providers.TracerProvider = traceProvider
+
+		initTracer = traceProvider.Tracer(instrumentationName)
+
+		tracer := newTracer(traceProvider.Tracer(cfg.ServiceName), cfg)
+		ctx = context.WithValue(ctx, TracerCtxKey{}, tracer)
+	}
+
+	if enabled(cfg.EnableMetrics) && exporterEnabled(metricsExporterEnv) {
+		var refresher *resourceRefresher
+		if cfg.ResourceRefreshInterval > 0 {
+			refresher = newResourceRefresher(resource)
+		}
+
+		meterProvider, err := setupMeterProvider(ctx, cfg, pipe, resource, refresher)
+		if err != nil {
+			return fail(err)
+		}
+		register = append(register, func() {
+			otel.SetMeterProvider(meterProvider)
+		})
+
+		shutdown = append(shutdown, meterProvider.Shutdown)
+		flush = append(flush, meterProvider.ForceFlush)
+		providers.MeterProvider = meterProvider
+
+		meter := newRegistryMeter(meterProvider.Meter(cfg.ServiceName), cfg.Instruments)
+		ctx = context.WithValue(ctx, MeterCtxKey{}, meter)
+
+		if refresher != nil {
+			refreshCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+			go refresher.run(refreshCtx, cfg, cfg.ResourceRefreshInterval)
+
+			shutdown = append(shutdown, func(context.Context) error {
+				cancel()
+				return nil
+			})
+		}
+
+		if cfg.CollectorHealthMetric {
+			watcher, err := watchCollectors(context.WithoutCancel(ctx), meterProvider.Meter(instrumentationName), targets)
+			if err != nil {
+				return fail(err)
+			}
+			pipe.watcher = watcher
+
+			shutdown = append(shutdown, func(context.Context) error {
+				watcher.stop()
+				return nil
+			})
+		}
+	}
+
+	if cfg.EnableLogs && exporterEnabled(logsExporterEnv) {
+		loggerProvider, err := setupLoggerProvider(ctx, cfg, pipe, resource)
+		if err != nil {
+			return fail(err)
+		}
+		shutdown = append(shutdown, loggerProvider.Shutdown)
+		flush = append(flush, loggerProvider.ForceFlush)
+		providers.LoggerProvider = loggerProvider
+
+		ctx = context.WithValue(ctx, LoggerCtxKey{}, loggerProvider)
+	}
+
+	shutdown = append(shutdown, func(context.Context) error {
+		activePipeline.CompareAndSwap(pipe, nil)
+		return pipe.close()
+	})
+
+	// package level state is only replaced once init can no longer fail, so a failed init keeps the previous
+	// registration
+	if capture := preInit.Swap(nil); capture != nil {
+		if providers.TracerProvider != nil {
+			// spans captured before init are flushed before the exporters they share shut down
+			shutdown = slices.Insert(shutdown, 0, capture.attach(providers.TracerProvider, pipe.spanExporters(), resource))
+		} else {
+			capture.discard(noop.NewTracerProvider())
+		}
+	}
+
+	for _, fn := range register {
+		fn()
+	}
+
+	errorStatusMapper.Store(&cfg.ErrorStatusMapper)
+	severityMapper.Store(&cfg.SeverityMapper)
+	debugRequests.Store(int64(cfg.MaxDebugRequests))
+	activePipeline.Store(pipe)
 
-	ctx = context.WithValue(ctx, TracerCtxKey{}, tracer)
-	ctx = context.WithValue(ctx, MeterCtxKey{}, meter)
+	timings.phase("exporter_setup")
+	if cfg.TraceInit && initTracer != nil {
+		timings.record(ctx, initTracer)
+	}
 
-	cleanup := func(ctx context.Context) {
+	telemetryCtx := ctx
+	providers.flush = flush
+	providers.shutdown = func(ctx context.Context) error {
 		var err error
+		if cfg.BeforeShutdown != nil {
+			err = providers.ForceFlush(ctx)
+
+			cfg.BeforeShutdown(withTelemetryValues(ctx, telemetryCtx))
+		}
+
 		for _, fn := range shutdown {
-			err = errors.Join(err, fn(ctx))
+			err = errors.Join(err, callWithTimeout(ctx, providers.timeout, fn))
 		}
 
-		if err != nil {
-			fmt.Fprintf(os.Stdout, "error shutting down telemetry providers: %s\n", err.Error())
+		return err
+	}
+
+	activeProviders.Store(providers)
+
+	return ctx, providers, nil
+}
+
+// validateConfig checks the options of the enabled signals that would otherwise only fail after the targets are dialed
+// and the earlier providers are registered
+func validateConfig(cfg *Config) error {
+	if _, err := lookupProfile(cfg.Profile); err != nil {
+		return err
+	}
+
+	if _, err := keyNormalizer(cfg.NormalizeAttributeKeys); err != nil {
+		return err
+	}
+
+	if enabled(cfg.EnableTraces) {
+		if cfg.Sampler == nil && (cfg.SampleRatio < 0 || cfg.SampleRatio > 1) {
+			return SampleRatioError{cfg.SampleRatio}
+		}
+
+		if cfg.DebugSampleRate < 0 || cfg.DebugSampleRate > 1 {
+			return DebugSampleRateError{cfg.DebugSampleRate}
+		}
+
+		if _, err := newPropagator(cfg); err != nil {
+			return err
+		}
+	}
+
+	if enabled(cfg.EnableMetrics) {
+		if cfg.MetricInterval < 0 {
+			return MetricIntervalError{cfg.MetricInterval}
+		}
+
+		if _, err := temporalitySelector(cfg.MetricTemporality); err != nil {
+			return err
+		}
+
+		for _, route := range cfg.MetricRoutes {
+			if len(route.Instruments) == 0 {
+				return MetricRouteError{route.Endpoint}
+			}
 		}
 	}
 
-	return ctx, cleanup, nil
+	if cfg.EnableLogs {
+		if _, err := minLogSeverity(cfg); err != nil {
+			return err
+		}
+
+		if _, err := newRedactLogProcessor(nil, cfg.RedactLogPatterns); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// setupResource creates a resouce with the supplied config and environment variables
-func setupResource(ctx context.Context, cfg *Config) (*resource.Resource, error) {
-	resourceFromEnv, err := resource.New(ctx, resource.WithFromEnv())
-	if err != nil {
-		return nil, ResourceEnvError{err}
+// initNoopProviders registers no-op providers globally and adds their tracer and meter to the context, see
+// Config.Disabled
+func initNoopProviders(ctx context.Context, cfg *Config) (context.Context, *Providers, error) {
+	traceProvider := noop.NewTracerProvider()
+	meterProvider := metricnoop.NewMeterProvider()
+
+	if capture := preInit.Swap(nil); capture != nil {
+		capture.discard(traceProvider)
 	}
 
-	var defaultResource *resource.Resource
-	defaultResource, err = resource.Merge(
-		resource.Default(),
-		resourceFromEnv,
-	)
+	otel.SetTracerProvider(traceProvider)
+	otel.SetMeterProvider(meterProvider)
+
+	if enabled(cfg.EnableTraces) {
+		ctx = context.WithValue(ctx, TracerCtxKey{}, traceProvider.Tracer(cfg.ServiceName))
+	}
+	if enabled(cfg.EnableMetrics) {
+		ctx = context.WithValue(ctx, MeterCtxKey{}, meterProvider.Meter(cfg.ServiceName))
+	}
+
+	if cfg.EnableLogs {
+		// a logger provider without processors drops every record
+		ctx = context.WithValue(ctx, LoggerCtxKey{}, sdklog.NewLoggerProvider())
+	}
+
+	providers := &Providers{shutdown: func(context.Context) error { return nil }}
+	activeProviders.Store(providers)
+
+	return ctx, providers, nil
+}
+
+// auditTimeout returns the configured audit timeout or the default
+func auditTimeout(cfg *Config) time.Duration {
+	if cfg.AuditTimeout > 0 {
+		return cfg.AuditTimeout
+	}
+
+	return defaultAuditTimeout
+}
+
+// shutdownTimeout returns the configured shutdown timeout or the default
+func shutdownTimeout(cfg *Config) time.Duration {
+	if cfg.ShutdownTimeout > 0 {
+		return cfg.ShutdownTimeout
+	}
+
+	return defaultShutdownTimeout
+}
+
+// callWithTimeout calls the shutdown func with a context derived from ctx that expires after the timeout
+func callWithTimeout(ctx context.Context, timeout time.Duration, fn func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+// enabled reports whether an optional signal flag is enabled, nil meaning enabled
+func enabled(flag *bool) bool {
+	return flag == nil || *flag
+}
+
+// exporterEnabled reports whether a signal is enabled by its exporter environment variable. Setting the variable to
+// "none" disables the signal, any other value is ignored since only OTLP exporters are supported
+func exporterEnabled(envKey string) bool {
+	return os.Getenv(envKey) != "none"
+}
+
+// newGrpcClient creates a gRPC connection to a collector. The connection is established lazily and uses plaintext
+// when no TLS config is given, see transportTLS
+func newGrpcClient(cfg *Config, endpoint string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+	}
+
+	if cfg.DialTimeout > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: cfg.DialTimeout,
+		}))
+	}
+
+	if cfg.WaitForReady {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	}
+
+	// applied last so they override the options above where they conflict
+	opts = append(opts, cfg.DialOptions...)
+
+	return grpc.NewClient(endpoint, opts...)
+}
+
+// setupResource creates a resource with the supplied config, environment variables, resource file and detectors.
+// For a given key the first source of Config.ResourcePriority wins, by default:
+//
+//  1. Config.ServiceName, Config.BuildInfo and Config.ResourceAttributes
+//  2. OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME
+//  3. Config.ResourceFile
+//  4. resource detectors, e.g. Lambda
+//  5. SDK defaults
+func setupResource(ctx context.Context, cfg *Config) (*resource.Resource, error) {
+	priority, err := resourcePriority(cfg)
 	if err != nil {
-		return nil, DefaultResourceError{err}
+		return nil, err
 	}
 
-	if cfg.Lambda {
-		detector := lambdadetector.NewResourceDetector()
-		lambdaResource, err := detector.Detect(ctx)
+	merged := resource.Empty()
+
+	// merged from the lowest priority so each source overrides the ones before it
+	for _, source := range slices.Backward(priority) {
+		sourceResource, err := resourceFromSource(ctx, cfg, source)
 		if err != nil {
-			return nil, LambdaResourceError{err}
+			return nil, err
 		}
 
-		defaultResource, err = resource.Merge(lambdaResource, defaultResource)
+		if sourceResource == nil {
+			continue
+		}
+
+		merged, err = mergeResources(cfg, merged, sourceResource)
 		if err != nil {
 			return nil, ResourceMergeError{err}
 		}
 	}
 
-	resource, err := resource.Merge(
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(cfg.ServiceName),
-		),
-		defaultResource,
-	)
-	if err != nil {
-		return nil, ResourceMergeError{err}
+	return merged, nil
+}
+
+// resourceFromSource creates the resource of a single source, or nil when the source is not configured
+func resourceFromSource(ctx context.Context, cfg *Config, source ResourceSource) (*resource.Resource, error) {
+	switch source {
+	case ResourceSourceExplicit:
+		attrs := cfg.ResourceAttributes
+		if cfg.BuildInfo != nil {
+			attrs = append(cfg.BuildInfo.attributes(), attrs...)
+		}
+		if cfg.ServiceName != "" {
+			attrs = append([]attribute.KeyValue{semconv.ServiceNameKey.String(cfg.ServiceName)}, attrs...)
+		}
+
+		return resource.NewWithAttributes(semconv.SchemaURL, attrs...), nil
+	case ResourceSourceEnv:
+		resourceFromEnv, err := resource.New(ctx, resource.WithFromEnv())
+		if err != nil {
+			return nil, ResourceEnvError{err}
+		}
+
+		return resourceFromEnv, nil
+	case ResourceSourceFile:
+		if cfg.ResourceFile == "" {
+			return nil, nil
+		}
+
+		fileResource, err := resourceFromFile(cfg.ResourceFile)
+		if err != nil {
+			return nil, ResourceFileError{err}
+		}
+
+		return fileResource, nil
+	case ResourceSourceDetectors:
+		return detectResource(ctx, cfg)
+	default:
+		// built from its detectors since resource.Default also reads the environment
+		defaultResource, err := resource.New(ctx,
+			resource.WithTelemetrySDK(),
+			resource.WithAttributes(semconv.ServiceName("unknown_service:"+filepath.Base(os.Args[0]))),
+		)
+		if err != nil {
+			return nil, DefaultResourceError{err}
+		}
+
+		return defaultResource, nil
 	}
+}
 
-	return resource, nil
+// mergeResources merges the resources, with b taking precedence. Schema URL conflicts are resolved by
+// Config.OnResourceConflict when set
+func mergeResources(cfg *Config, a, b *resource.Resource) (*resource.Resource, error) {
+	merged, err := resource.Merge(a, b)
+	if err != nil && cfg.OnResourceConflict != nil && errors.Is(err, resource.ErrSchemaURLConflict) {
+		return cfg.OnResourceConflict(a, b)
+	}
+
+	return merged, err
 }
 
-// setupTraceProvider configures a trace provider
-func setupTraceProvider(ctx context.Context, conn *grpc.ClientConn, resource *resource.Resource) (*sdktrace.TracerProvider, error) {
-	traceExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
+// setupTraceProvider configures a trace provider. The returned function registers it, its propagator and its debug
+// span buffer globally
+func setupTraceProvider(ctx context.Context, cfg *Config, pipe *pipeline, resource *resource.Resource) (*sdktrace.TracerProvider, func(), error) {
+	propagator, err := newPropagator(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	traceProvider, err := newTraceProvider(ctx, cfg, pipe.targets, resource, pipe)
 	if err != nil {
-		return nil, TraceExporterError{err}
+		return nil, nil, err
 	}
 
-	traceProvider := sdktrace.NewTracerProvider(
+	var buffer *spanBuffer
+	if cfg.DebugSpanBufferSize > 0 {
+		buffer, err = newSpanBuffer(cfg.DebugSpanBufferSize, cfg.DebugSampleRate)
+		if err != nil {
+			return nil, nil, errors.Join(err, traceProvider.Shutdown(ctx))
+		}
+
+		traceProvider.RegisterSpanProcessor(buffer)
+	}
+
+	register := func() {
+		if buffer != nil {
+			recentSpans.Store(buffer)
+		}
+
+		draining.Store(false)
+
+		otel.SetTracerProvider(traceProvider)
+		otel.SetTextMapPropagator(propagator)
+	}
+
+	return traceProvider, register, nil
+}
+
+// newTraceProvider creates a trace provider exporting to every target without registering it globally. Exporters are
+// tracked by the pipeline when it is not nil
+func newTraceProvider(ctx context.Context, cfg *Config, targets []target, resource *resource.Resource, pipe *pipeline) (*sdktrace.TracerProvider, error) {
+	settings := profiles[cfg.Profile]
+
+	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(resource),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
-		sdktrace.WithSpanProcessor(sdktrace.NewBatchSpanProcessor(traceExporter)),
-	)
+	}
 
-	otel.SetTracerProvider(traceProvider)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		xray.Propagator{},
-	))
+	if cfg.RecordCodeAttributes {
+		opts = append(opts, sdktrace.WithSpanProcessor(codeAttributesProcessor{}))
+	}
+
+	var traceExporters []sdktrace.SpanExporter
+	for i, target := range targets {
+		if !target.receives(signalTraces) {
+			continue
+		}
+
+		traceExporter, err := newSpanExporter(ctx, cfg, target)
+		if err != nil {
+			return nil, err
+		}
+
+		traceExporters = append(traceExporters, pipe.spanExporter(i, traceExporter))
+	}
+
+	if cfg.FanOut.Sequential && len(traceExporters) > 1 {
+		traceExporters = []sdktrace.SpanExporter{sequentialSpanExporter{traceExporters, cfg.FanOut.StopOnError}}
+	}
+
+	// config options are applied last so they override the profile
+	batchOptions := slices.Concat(settings.batchSpanOptions(), cfg.BatchSpan.options())
+
+	for _, traceExporter := range traceExporters {
+		var processor sdktrace.SpanProcessor = sdktrace.NewBatchSpanProcessor(traceExporter, batchOptions...)
+		if cfg.MaxSpanEvents > 0 {
+			processor = eventLimitProcessor{processor, cfg.MaxSpanEvents}
+		}
+		if cfg.ErrorSampleQuota > 0 {
+			processor = newErrorQuotaProcessor(processor, cfg.ErrorSampleQuota)
+		}
+		if cfg.KeepSlowTracesOver > 0 {
+			processor = newSlowTraceProcessor(processor, cfg.KeepSlowTracesOver)
+		}
+		if cfg.EnableAudit {
+			processor = auditSpanProcessor{processor, traceExporter, auditTimeout(cfg)}
+		}
+
+		opts = append(opts, sdktrace.WithSpanProcessor(processor))
+	}
 
-	return traceProvider, nil
+	sampler := cfg.Sampler
+	switch {
+	case sampler != nil:
+	case cfg.SampleRatio < 0 || cfg.SampleRatio > 1:
+		return nil, SampleRatioError{cfg.SampleRatio}
+	case cfg.SampleRatio > 0:
+		sampler = sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))
+	default:
+		sampler = settings.sampler()
+	}
+
+	if cfg.EnableAudit {
+		sampler = auditSampler{sampler}
+	}
+
+	if cfg.DebugSpanBufferSize > 0 || cfg.ErrorSampleQuota > 0 || cfg.KeepSlowTracesOver > 0 {
+		sampler = recordingSampler{sampler}
+	}
+
+	sampler = drainSampler{sampler}
+
+	return sdktrace.NewTracerProvider(append(opts, sdktrace.WithSampler(sampler))...), nil
 }
 
-// setupMeterProvider configures a meter provider
-func setupMeterProvider(ctx context.Context, conn *grpc.ClientConn, resource *resource.Resource) (*sdkmetric.MeterProvider, error) {
-	metricExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithGRPCConn(conn))
-	if err != nil {
-		return nil, MetricExporterError{err}
+// setupMeterProvider configures a meter provider without registering it globally. Exported metrics carry the latest resource of the refresher when it
+// is not nil
+func setupMeterProvider(ctx context.Context, cfg *Config, pipe *pipeline, resource *resource.Resource, refresher *resourceRefresher) (*sdkmetric.MeterProvider, error) {
+	settings := profiles[cfg.Profile]
+
+	if cfg.MetricInterval < 0 {
+		return nil, MetricIntervalError{cfg.MetricInterval}
+	}
+
+	interval := defaultMetricInterval
+	switch {
+	case cfg.MetricInterval > 0:
+		interval = cfg.MetricInterval
+	case settings.metricInterval > 0:
+		interval = settings.metricInterval
 	}
 
-	meterProvider := sdkmetric.NewMeterProvider(
+	opts := []sdkmetric.Option{
 		sdkmetric.WithResource(resource),
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(
-			metricExporter,
-			sdkmetric.WithInterval(1*time.Second),
-		)),
-	)
+	}
 
-	otel.SetMeterProvider(meterProvider)
+	if len(cfg.ExemplarReservoirs) > 0 || len(cfg.HistogramBuckets) > 0 {
+		opts = append(opts, sdkmetric.WithView(instrumentViews(cfg.ExemplarReservoirs, cfg.HistogramBuckets)...))
+	}
+
+	if len(cfg.Views) > 0 {
+		opts = append(opts, sdkmetric.WithView(cfg.Views...))
+	}
+
+	var routed []string
+	for _, route := range cfg.MetricRoutes {
+		routed = append(routed, route.Instruments...)
+	}
+
+	var collectors, routes []sdkmetric.Exporter
+	for i, target := range pipe.targets {
+		if !target.receives(signalMetrics) {
+			continue
+		}
+
+		metricExporter, err := newMetricExporter(ctx, cfg, target)
+		if err != nil {
+			return nil, err
+		}
+		metricExporter = pipe.metricExporter(i, metricExporter)
+
+		switch {
+		case target.metricsOnly():
+			routes = append(routes, routeMetricExporter{metricExporter, target.instruments, true})
+		case len(routed) > 0:
+			collectors = append(collectors, routeMetricExporter{metricExporter, routed, false})
+		default:
+			collectors = append(collectors, metricExporter)
+		}
+	}
+
+	if cfg.FanOut.Sequential && len(collectors) > 1 {
+		collectors = []sdkmetric.Exporter{sequentialMetricExporter{collectors[0], collectors, cfg.FanOut.StopOnError}}
+	}
 
-	return meterProvider, nil
+	readerOpts := []sdkmetric.PeriodicReaderOption{sdkmetric.WithInterval(interval)}
+	if cfg.MetricExportTimeout > 0 {
+		readerOpts = append(readerOpts, sdkmetric.WithTimeout(cfg.MetricExportTimeout))
+	}
+
+	for _, metricExporter := range append(collectors, routes...) {
+		opts = append(opts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(
+			refresher.exporter(metricExporter),
+			readerOpts...,
+		)))
+	}
+
+	return sdkmetric.NewMeterProvider(opts...), nil
 }
 
-// setupLoggerProvider configures a logger provider and adds it to the context. Feature still in BETA
-func setupLoggerProvider(ctx context.Context, conn *grpc.ClientConn, resource *resource.Resource) (context.Context, error) {
-	logExporter, err := otlploggrpc.New(ctx, otlploggrpc.WithGRPCConn(conn))
+// setupLoggerProvider configures a logger provider. Feature still in BETA
+func setupLoggerProvider(ctx context.Context, cfg *Config, pipe *pipeline, resource *resource.Resource) (*sdklog.LoggerProvider, error) {
+	settings := profiles[cfg.Profile]
+
+	minSeverity, err := minLogSeverity(cfg)
 	if err != nil {
-		return ctx, LogExporterError{err}
+		return nil, err
 	}
 
-	loggerProvider := sdklog.NewLoggerProvider(
+	opts := []sdklog.LoggerProviderOption{
 		sdklog.WithResource(resource),
-		sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)),
-	)
+	}
+
+	if len(cfg.RedactLogAttributes) > 0 || len(cfg.RedactLogPatterns) > 0 {
+		redact, err := newRedactLogProcessor(cfg.RedactLogAttributes, cfg.RedactLogPatterns)
+		if err != nil {
+			return nil, err
+		}
 
-	ctx = context.WithValue(ctx, LoggerCtxKey{}, loggerProvider)
+		// registered first so every exporting processor receives the redacted record
+		opts = append(opts, sdklog.WithProcessor(redact))
+	}
 
-	return ctx, nil
+	var logExporters []sdklog.Exporter
+	for i, target := range pipe.targets {
+		if !target.receives(signalLogs) {
+			continue
+		}
+
+		logExporter, err := newLogExporter(ctx, cfg, target)
+		if err != nil {
+			return nil, err
+		}
+
+		logExporters = append(logExporters, pipe.logExporter(i, logExporter))
+	}
+
+	if cfg.FanOut.Sequential && len(logExporters) > 1 {
+		logExporters = []sdklog.Exporter{sequentialLogExporter{logExporters, cfg.FanOut.StopOnError}}
+	}
+
+	for _, logExporter := range logExporters {
+		var processor sdklog.Processor = sdklog.NewBatchProcessor(logExporter, settings.batchLogOptions()...)
+		if cfg.LogsFollowTraceSampling {
+			processor = traceSampledProcessor{processor}
+		}
+		if minSeverity != log.SeverityUndefined {
+			processor = minSeverityProcessor{processor, minSeverity}
+		}
+		if cfg.EnableAudit {
+			processor = auditLogProcessor{processor, logExporter, auditTimeout(cfg)}
+		}
+
+		opts = append(opts, sdklog.WithProcessor(processor))
+	}
+
+	if cfg.LogToStdout {
+		stdoutExporter, err := newStdoutLogExporter(cfg.StdoutFormat, os.Stdout)
+		if err != nil {
+			return nil, LogExporterError{err}
+		}
+
+		var processor sdklog.Processor = sdklog.NewSimpleProcessor(stdoutExporter)
+		if minSeverity != log.SeverityUndefined {
+			processor = minSeverityProcessor{processor, minSeverity}
+		}
+
+		opts = append(opts, sdklog.WithProcessor(processor))
+	}
+
+	return sdklog.NewLoggerProvider(opts...), nil
 }
 
 // AddTracerContext adds the tracer to the context
@@ -199,6 +1060,23 @@ func AddMeterContext(ctx context.Context, meter metric.Meter) context.Context {
 	return context.WithValue(ctx, MeterCtxKey{}, meter)
 }
 
+// WorkerContext returns a context for long-lived background workers. It carries the tracer, meter, logger provider
+// and resource from the base context but none of its other values, cancellation or deadline
+func WorkerContext(base context.Context) context.Context {
+	return withTelemetryValues(context.Background(), base)
+}
+
+// withTelemetryValues copies the tracer, meter, logger provider and resource from src into ctx
+func withTelemetryValues(ctx context.Context, src context.Context) context.Context {
+	for _, key := range []any{TracerCtxKey{}, MeterCtxKey{}, LoggerCtxKey{}, ResourceCtxKey{}} {
+		if value := src.Value(key); value != nil {
+			ctx = context.WithValue(ctx, key, value)
+		}
+	}
+
+	return ctx
+}
+
 // TracerFromContext checks the context for a tracer. The returned value can be nil
 func TracerFromContext(ctx context.Context) (trace.Tracer, error) {
 	tracer, ok := ctx.Value(TracerCtxKey{}).(trace.Tracer)
@@ -228,3 +1106,44 @@ func LogProviderFromContext(ctx context.Context) (*sdklog.LoggerProvider, error)
 
 	return logProvider, nil
 }
+
+// LoggerFromContext returns the named logger of the logger provider in the context
+func LoggerFromContext(ctx context.Context, name string, opts ...log.LoggerOption) (log.Logger, error) {
+	logProvider, err := LogProviderFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if logProvider == nil {
+		return nil, LogProviderError{}
+	}
+
+	return logProvider.Logger(name, opts...), nil
+}
+
+// ResourceFromContext checks the context for the resource created by InitProviders. The returned value can be nil
+func ResourceFromContext(ctx context.Context) (*resource.Resource, error) {
+	res, ok := ctx.Value(ResourceCtxKey{}).(*resource.Resource)
+	if !ok {
+		return nil, ResourceError{}
+	}
+
+	return res, nil
+}
+
+// ResourceString renders the resolved resource attributes in the context as sorted key=value lines, which helps
+// verify what detectors and environment variables produced. An empty string is returned when no resource is present
+func ResourceString(ctx context.Context) string {
+	res, err := ResourceFromContext(ctx)
+	if err != nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for iter := res.Iter(); iter.Next(); {
+		attr := iter.Attribute()
+		sb.WriteString(string(attr.Key) + "=" + attr.Value.Emit() + "\n")
+	}
+
+	return sb.String()
+}
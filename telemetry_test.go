@@ -0,0 +1,79 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestNewProvidersInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *Config
+		env     string
+		wantErr any
+	}{
+		{name: "sample ratio", cfg: &Config{SampleRatio: 2}, wantErr: &SampleRatioError{}},
+		{name: "metric interval", cfg: &Config{MetricInterval: -time.Second}, wantErr: &MetricIntervalError{}},
+		{name: "temporality", cfg: &Config{MetricTemporality: "sometimes"}, wantErr: &TemporalityError{}},
+		{name: "key convention", cfg: &Config{NormalizeAttributeKeys: "kebab"}, wantErr: &KeyConventionError{}},
+		{name: "redact pattern", cfg: &Config{EnableLogs: true, RedactLogPatterns: []string{"("}}, wantErr: &RedactPatternError{}},
+		{name: "min log severity", cfg: &Config{EnableLogs: true}, env: "loud", wantErr: &LogSeverityError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(minLogSeverityEnv, tt.env)
+
+			tt.cfg.ServiceName = "test"
+			tt.cfg.Exporter = ExporterStdout
+
+			tracerProvider := otel.GetTracerProvider()
+
+			_, providers, err := NewProviders(context.Background(), tt.cfg)
+			if !errors.As(err, tt.wantErr) {
+				t.Fatalf("NewProviders() error = %v, want %T", err, tt.wantErr)
+			}
+
+			if providers != nil {
+				t.Errorf("NewProviders() providers = %v, want nil", providers)
+			}
+
+			if otel.GetTracerProvider() != tracerProvider {
+				t.Error("NewProviders() registered a tracer provider despite failing")
+			}
+		})
+	}
+}
+
+func TestNewProvidersFailedInitKeepsState(t *testing.T) {
+	tracerProvider := otel.GetTracerProvider()
+	meterProvider := otel.GetMeterProvider()
+	mapper := errorStatusMapper.Load()
+	active := activeProviders.Load()
+
+	cfg := &Config{
+		ServiceName:       "test",
+		Exporter:          ExporterStdout,
+		StdoutFormat:      "xml",
+		ErrorStatusMapper: func(error) (codes.Code, string) { return codes.Ok, "" },
+		MaxDebugRequests:  10,
+	}
+
+	_, _, err := NewProviders(context.Background(), cfg)
+	if !errors.As(err, &StdoutFormatError{}) {
+		t.Fatalf("NewProviders() error = %v, want StdoutFormatError", err)
+	}
+
+	if otel.GetTracerProvider() != tracerProvider || otel.GetMeterProvider() != meterProvider {
+		t.Error("NewProviders() registered global providers despite failing")
+	}
+
+	if errorStatusMapper.Load() != mapper || activeProviders.Load() != active || debugRequests.Load() != 0 {
+		t.Error("NewProviders() stored package state despite failing")
+	}
+}
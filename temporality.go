@@ -0,0 +1,55 @@
+package telemetry
+
+import (
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// TemporalityCumulative exports metrics as running totals since process start. This is the default
+	TemporalityCumulative = "cumulative"
+
+	// TemporalityDelta converts cumulative counters and histograms to deltas in-process before export, allowing
+	// delta-only backends to be used without a collector side cumulativetodelta processor
+	TemporalityDelta = "delta"
+
+	// TemporalityLowMemory uses delta temporality for synchronous counters and histograms and cumulative temporality
+	// otherwise, so the SDK does not keep running totals of synchronous instruments
+	TemporalityLowMemory = "lowmemory"
+)
+
+// temporalitySelector returns the temporality selector for the configured metric temporality
+func temporalitySelector(temporality string) (sdkmetric.TemporalitySelector, error) {
+	switch temporality {
+	case "", TemporalityCumulative:
+		return sdkmetric.DefaultTemporalitySelector, nil
+	case TemporalityDelta:
+		return deltaTemporalitySelector, nil
+	case TemporalityLowMemory:
+		return lowMemoryTemporalitySelector, nil
+	default:
+		return nil, TemporalityError{temporality}
+	}
+}
+
+// deltaTemporalitySelector uses delta temporality for monotonic instruments. UpDownCounters stay cumulative since
+// their deltas are meaningless without the running total
+func deltaTemporalitySelector(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindUpDownCounter, sdkmetric.InstrumentKindObservableUpDownCounter:
+		return metricdata.CumulativeTemporality
+	default:
+		return metricdata.DeltaTemporality
+	}
+}
+
+// lowMemoryTemporalitySelector uses delta temporality for synchronous counters and histograms. Observable instruments
+// report cumulative values already and UpDownCounters need the running total
+func lowMemoryTemporalitySelector(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindHistogram:
+		return metricdata.DeltaTemporality
+	default:
+		return metricdata.CumulativeTemporality
+	}
+}
@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultsTracer applies the span attribute template of the context and default span start options to every span.
+// Options passed to Start are applied last so they override both
+type defaultsTracer struct {
+	trace.Tracer
+	defaults []trace.SpanStartOption
+}
+
+// newTracer wraps the tracer with the default span start options of the config
+func newTracer(tracer trace.Tracer, cfg *Config) trace.Tracer {
+	defaults := make([]trace.SpanStartOption, 0, len(cfg.DefaultSpanStartOptions)+1)
+	if cfg.DefaultSpanKind != trace.SpanKindUnspecified {
+		defaults = append(defaults, trace.WithSpanKind(cfg.DefaultSpanKind))
+	}
+	defaults = append(defaults, cfg.DefaultSpanStartOptions...)
+
+	return &defaultsTracer{
+		Tracer:   tracer,
+		defaults: defaults,
+	}
+}
+
+// globalTracer returns a tracer of the global tracer provider that applies the span attribute template
+func globalTracer() trace.Tracer {
+	return &defaultsTracer{Tracer: otel.Tracer(instrumentationName)}
+}
+
+func (t *defaultsTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	template := spanAttributes(ctx)
+	if len(template) == 0 && len(t.defaults) == 0 {
+		return t.Tracer.Start(ctx, name, opts...)
+	}
+
+	all := make([]trace.SpanStartOption, 0, len(t.defaults)+len(opts)+1)
+	if len(template) > 0 {
+		all = append(all, trace.WithAttributes(template...))
+	}
+	all = append(all, t.defaults...)
+
+	return t.Tracer.Start(ctx, name, append(all, opts...)...)
+}
+
+// WithSpanAttributes returns a context whose attributes are added to every span started from it, or from contexts
+// derived from it, with the tracer in the context. Attributes accumulate over nested calls and attributes passed when
+// starting a span take precedence
+func WithSpanAttributes(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	template := slices.Concat(spanAttributes(ctx), attrs)
+
+	return context.WithValue(ctx, SpanAttributesCtxKey{}, template)
+}
+
+// spanAttributes returns the span attribute template of the context
+func spanAttributes(ctx context.Context) []attribute.KeyValue {
+	template, _ := ctx.Value(SpanAttributesCtxKey{}).([]attribute.KeyValue)
+	return template
+}
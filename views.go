@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// instrumentViews creates a single view per instrument named in the exemplar reservoirs or histogram buckets, since
+// every matching view produces its own stream. Reservoirs keep size exemplars per data point instead of the default
+// of one per histogram bucket or one per CPU, and buckets replace the default explicit bucket boundaries
+func instrumentViews(reservoirs map[string]int, buckets map[string][]float64) []sdkmetric.View {
+	names := slices.Collect(maps.Keys(reservoirs))
+	for name := range buckets {
+		if _, ok := reservoirs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	views := make([]sdkmetric.View, 0, len(names))
+	for _, name := range names {
+		var stream sdkmetric.Stream
+
+		if size := reservoirs[name]; size > 0 {
+			stream.ExemplarReservoirProviderSelector = func(sdkmetric.Aggregation) exemplar.ReservoirProvider {
+				return exemplar.FixedSizeReservoirProvider(size)
+			}
+		}
+
+		if boundaries, ok := buckets[name]; ok {
+			stream.Aggregation = sdkmetric.AggregationExplicitBucketHistogram{Boundaries: boundaries}
+		}
+
+		if stream.ExemplarReservoirProviderSelector == nil && stream.Aggregation == nil {
+			continue
+		}
+
+		views = append(views, sdkmetric.NewView(sdkmetric.Instrument{Name: name}, stream))
+	}
+
+	return views
+}
@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestHistogramBoundaries(t *testing.T) {
+	boundaries := []float64{0.05, 0.1, 0.25, 0.5, 1}
+
+	tests := []struct {
+		name  string
+		views []sdkmetric.View
+	}{
+		{
+			name:  "HistogramBuckets",
+			views: instrumentViews(nil, map[string][]float64{"latency": boundaries}),
+		},
+		{
+			name: "Views",
+			views: []sdkmetric.View{
+				sdkmetric.NewView(
+					sdkmetric.Instrument{Name: "latency"},
+					sdkmetric.Stream{Aggregation: sdkmetric.AggregationExplicitBucketHistogram{Boundaries: boundaries}},
+				),
+			},
+		},
+		{
+			name:  "HistogramBuckets with exemplar reservoir",
+			views: instrumentViews(map[string]int{"latency": 4}, map[string][]float64{"latency": boundaries}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			reader := sdkmetric.NewManualReader()
+			provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(tt.views...))
+			defer provider.Shutdown(ctx)
+
+			histogram, err := provider.Meter("test").Float64Histogram("latency")
+			if err != nil {
+				t.Fatalf("Float64Histogram() error = %v", err)
+			}
+
+			histogram.Record(ctx, 0.2)
+
+			var rm metricdata.ResourceMetrics
+			if err := reader.Collect(ctx, &rm); err != nil {
+				t.Fatalf("Collect() error = %v", err)
+			}
+
+			if len(rm.ScopeMetrics) != 1 || len(rm.ScopeMetrics[0].Metrics) != 1 {
+				t.Fatalf("collected %+v, want a single latency stream", rm.ScopeMetrics)
+			}
+
+			hist, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+			if !ok || len(hist.DataPoints) != 1 {
+				t.Fatalf("latency = %+v, want a single histogram data point", rm.ScopeMetrics[0].Metrics[0].Data)
+			}
+
+			if got := hist.DataPoints[0].Bounds; !slices.Equal(got, boundaries) {
+				t.Errorf("bounds = %v, want %v", got, boundaries)
+			}
+		})
+	}
+}